@@ -191,8 +191,8 @@ const ApacheTemplateBody = `                               Apache License
       same "printed page" as the copyright notice for easier
       identification within third-party archives.
 
-   Copyright {{.StartYear}}{{if (gt .EndYear 0) }}-{{.EndYear}}{{end}} {{.Holder}}
-
+{{range .}}   Copyright {{.Years}} {{.Credit}}
+{{end}}
    Licensed under the Apache License, Version 2.0 (the "License");
    you may not use this file except in compliance with the License.
    You may obtain a copy of the License at
@@ -0,0 +1,362 @@
+package ligen
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+var MissingLicenseFileError = errors.New("missing license file for license type")
+
+// CombinedStatementInput contains the information needed to render the statement
+// that ties the files of a multi-license project together.
+type CombinedStatementInput struct {
+	Expression string
+	Summary    string
+	Files      []CombinedStatementFile
+	// Incorporated are the licenses the licenses of the expression build on, like the GPL for the LGPL
+	Incorporated []CombinedStatementFile
+}
+
+// CombinedStatementFile points from a license to the file holding its text,
+// along with the exception the license is used with, if any.
+type CombinedStatementFile struct {
+	SPDXID    string
+	Exception string
+	Path      string
+}
+
+// Template for the LICENSE file of a multi-license project
+const CombinedStatementTemplateBody = `SPDX-License-Identifier: {{.Expression}}
+
+{{.Summary}}
+{{range .Files}}
+  * {{.SPDXID}}{{with .Exception}} WITH {{.}}{{end}} ({{.Path}}){{end}}
+{{with .Incorporated}}
+The licenses above incorporate the following licenses:
+{{range .}}
+  * {{.SPDXID}} ({{.Path}}){{end}}
+{{end}}`
+
+var CombinedStatementTemplate = template.Must(template.New("CombinedStatement").Parse(CombinedStatementTemplateBody))
+
+// combinedSummary describes how the licenses of an expression apply, in words
+func combinedSummary(expression Expression) string {
+	for _, operand := range expression.Operands {
+		if operand.Operator != SIMPLE_EXPRESSION {
+			return "This project is licensed under the license expression above. The text of each license is in its own file:"
+		}
+	}
+
+	switch expression.Operator {
+	case OR_EXPRESSION:
+		return "This project is licensed under either of the following licenses, at your option:"
+	case AND_EXPRESSION:
+		return "This project is licensed under all of the following licenses:"
+	default:
+		return "This project is licensed under the following license:"
+	}
+}
+
+// licenseFileSuffix returns the short name that tells license files apart in a multi-license
+// layout, the SPDX identifier without its version, e.g. "APACHE" for LICENSE-APACHE.
+func licenseFileSuffix(licenseType LicenseType) string {
+	segments := strings.Split(strings.TrimPrefix(licenseType.SPDXID(), "LicenseRef-"), "-")
+
+	kept := segments[:1]
+	for _, segment := range segments[1:] {
+		if segment[0] >= '0' && segment[0] <= '9' {
+			break
+		}
+
+		kept = append(kept, segment)
+	}
+
+	return strings.ToUpper(strings.Join(kept, "-"))
+}
+
+// combinedLicensePaths names the license file of each license type in a multi-license layout,
+// and of the licenses they incorporate. When the short names of two license types collide,
+// e.g. BSD-2-Clause and BSD-3-Clause, both use their full SPDX identifier instead.
+func combinedLicensePaths(licenseTypes []LicenseType) map[LicenseType]string {
+	licenseTypes = withIncorporatedLicenses(licenseTypes)
+
+	counts := make(map[string]int, len(licenseTypes))
+	for _, licenseType := range licenseTypes {
+		counts[licenseFileSuffix(licenseType)]++
+	}
+
+	paths := make(map[LicenseType]string, len(licenseTypes))
+	for _, licenseType := range licenseTypes {
+		suffix := licenseFileSuffix(licenseType)
+		if counts[suffix] > 1 {
+			suffix = strings.ToUpper(strings.TrimPrefix(licenseType.SPDXID(), "LicenseRef-"))
+		}
+
+		paths[licenseType] = "LICENSE-" + suffix
+	}
+
+	return paths
+}
+
+// mergeNotices combines the NOTICE files of several licenses into one.
+// The project name is written once, as is each copyright line, and paragraphs
+// shared between notices are only kept the first time they appear.
+func mergeNotices(projectName string, notices []string) string {
+	var paragraphs []string
+	seen := make(map[string]bool)
+	var copyrightsWritten []Copyright
+
+	for _, notice := range notices {
+		// The first line of every notice is the project name
+		_, body, _ := strings.Cut(strings.ReplaceAll(notice, "\r\n", "\n"), "\n")
+
+		for _, paragraph := range strings.Split(body, "\n\n") {
+			var kept []string
+			for _, line := range strings.Split(paragraph, "\n") {
+				if copyright, err := ParseCopyright(line); err == nil {
+					if slices.ContainsFunc(copyrightsWritten, copyright.Equal) {
+						continue
+					}
+					copyrightsWritten = append(copyrightsWritten, copyright)
+				}
+
+				kept = append(kept, line)
+			}
+
+			text := strings.TrimSpace(strings.Join(kept, "\n"))
+			if text == "" || seen[text] {
+				continue
+			}
+
+			seen[text] = true
+			paragraphs = append(paragraphs, text)
+		}
+	}
+
+	return projectName + "\n" + strings.Join(paragraphs, "\n\n") + "\n"
+}
+
+// renderedLicense is the text of one license in a multi-license project,
+// along with its NOTICE if it requires one
+type renderedLicense struct {
+	licenseType LicenseType
+	text        string
+	notice      string
+	// incorporated is set for licenses rendered only because another license incorporates them
+	incorporated bool
+}
+
+// renderEach generates the text and NOTICE of every license covered by the License.
+// The text of a license another one incorporates, like the GPL the GNU Lesser license builds on,
+// is rendered on its own after the licenses of the expression, unless it's one of them.
+func (l *License) renderEach() ([]renderedLicense, error) {
+	expression := l.licenseExpression()
+	licenseTypes := expression.LicenseTypes()
+
+	var content bytes.Buffer
+	rendered := make([]renderedLicense, 0, len(licenseTypes))
+	incorporatedTexts := make(map[LicenseType]string)
+
+	for _, licenseType := range licenseTypes {
+		generatorFunc, err := licenseType.GeneratorFunc()
+		if err != nil {
+			return nil, err
+		}
+
+		writeables, err := generatorFunc(&l.projectName, &l.copyrights, &l.parameters, &content)
+		if err != nil {
+			return nil, err
+		}
+
+		current := renderedLicense{licenseType: licenseType}
+
+		incorporated, incorporates := licenseType.incorporatedLicense()
+		var incorporatedText string
+		if incorporates {
+			if incorporatedText, err = incorporated.Template(); err != nil {
+				return nil, err
+			}
+		}
+
+		var text strings.Builder
+		for _, writeable := range writeables {
+			if writeable.Path == "NOTICE" {
+				current.notice = writeable.Content
+				continue
+			}
+
+			if incorporates && writeable.Content == incorporatedText {
+				incorporatedTexts[incorporated] = writeable.Content
+				continue
+			}
+
+			if text.Len() > 0 && !strings.HasSuffix(text.String(), "\n") {
+				text.WriteString("\n")
+			}
+			text.WriteString(writeable.Content)
+		}
+		current.text = text.String()
+
+		rendered = append(rendered, current)
+	}
+
+	for _, licenseType := range withIncorporatedLicenses(licenseTypes)[len(licenseTypes):] {
+		rendered = append(rendered, renderedLicense{licenseType: licenseType, text: incorporatedTexts[licenseType], incorporated: true})
+	}
+
+	return rendered, nil
+}
+
+// mergedNotice renders the NOTICE shared by all the licenses. Returns false when none of them requires one.
+func (l *License) mergedNotice(rendered []renderedLicense) (Writeable, bool) {
+	var notices []string
+	for _, current := range rendered {
+		if current.notice != "" {
+			notices = append(notices, current.notice)
+		}
+	}
+
+	if len(notices) == 0 {
+		return Writeable{}, false
+	}
+
+	return Writeable{Content: mergeNotices(l.projectName, notices), Path: "NOTICE"}, true
+}
+
+// renderCombined generates the files of a multi-license project, or of a license with an exception:
+// a LICENSE with the combined statement naming the exceptions, a LICENSE-<NAME> file with the text
+// of each license and of each license they incorporate, and a single NOTICE merged from the notices
+// of the licenses that require one.
+func (l *License) renderCombined() ([]Writeable, error) {
+	rendered, err := l.renderEach()
+	if err != nil {
+		return nil, err
+	}
+
+	licenseTypes := make([]LicenseType, len(rendered))
+	for idx, current := range rendered {
+		licenseTypes[idx] = current.licenseType
+	}
+	paths := combinedLicensePaths(licenseTypes)
+
+	statementInput := CombinedStatementInput{
+		Expression: l.expression.String(),
+		Summary:    combinedSummary(*l.expression),
+	}
+
+	exceptions := l.expression.exceptions()
+
+	licenseFiles := make([]Writeable, 0, len(rendered))
+	for _, current := range rendered {
+		path := paths[current.licenseType]
+
+		licenseFiles = append(licenseFiles, Writeable{Content: current.text, Path: path})
+
+		if current.incorporated {
+			statementInput.Incorporated = append(statementInput.Incorporated, CombinedStatementFile{SPDXID: current.licenseType.SPDXID(), Path: path})
+			continue
+		}
+
+		statementInput.Files = append(statementInput.Files, CombinedStatementFile{
+			SPDXID:    current.licenseType.SPDXID(),
+			Exception: exceptions[current.licenseType],
+			Path:      path,
+		})
+	}
+
+	var content bytes.Buffer
+	if err := CombinedStatementTemplate.Execute(&content, &statementInput); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 0, len(licenseFiles)+2)
+	writeableSlice = append(writeableSlice, Writeable{Content: content.String(), Path: "LICENSE"})
+	writeableSlice = append(writeableSlice, licenseFiles...)
+
+	if notice, ok := l.mergedNotice(rendered); ok {
+		writeableSlice = append(writeableSlice, notice)
+	}
+
+	return writeableSlice, nil
+}
+
+// mergeParameters fills the parameters left empty in p with the ones set in other
+func mergeParameters(p Parameters, other Parameters) Parameters {
+	if p.SecondaryLicense == "" {
+		p.SecondaryLicense = other.SecondaryLicense
+	}
+	if p.Licensor == "" {
+		p.Licensor = other.Licensor
+	}
+	if p.LicensedWork == "" {
+		p.LicensedWork = other.LicensedWork
+	}
+	if p.AdditionalUseGrant == "" {
+		p.AdditionalUseGrant = other.AdditionalUseGrant
+	}
+	if p.ChangeDate.IsZero() {
+		p.ChangeDate = other.ChangeDate
+	}
+	if p.ChangeLicense == "" {
+		p.ChangeLicense = other.ChangeLicense
+	}
+	if p.ConfidentialityClause == "" {
+		p.ConfidentialityClause = other.ConfidentialityClause
+	}
+	if p.ContactEmail == "" {
+		p.ContactEmail = other.ContactEmail
+	}
+
+	return p
+}
+
+// combineParts populates a multi-license License from the licenses loaded out of each of its files.
+func combineParts(license *License, expression Expression, parts []License) error {
+	var combined License
+
+	for _, part := range parts {
+		if combined.projectName == "" {
+			combined.projectName = part.projectName
+		}
+
+		if len(combined.copyrights) == 0 {
+			combined.copyrights = part.copyrights
+		}
+
+		combined.parameters = mergeParameters(combined.parameters, part.parameters)
+	}
+
+	if err := combined.SetExpression(expression); err != nil {
+		return err
+	}
+
+	*license = combined
+
+	return nil
+}
+
+// LoadCombined loads a multi-license project and populates the License.
+// licenseLoaders provides the license file content for each license type in the expression,
+// and noticeLoader the content of the NOTICE shared between them.
+func LoadCombined(license *License, expression Expression, licenseLoaders map[LicenseType]loader, noticeLoader loader) error {
+	var parts []License
+
+	for _, licenseType := range expression.LicenseTypes() {
+		licenseLoader, ok := licenseLoaders[licenseType]
+		if !ok {
+			return MissingLicenseFileError
+		}
+
+		var part License
+		if err := Load(&part, licenseLoader, noticeLoader); err != nil {
+			return err
+		}
+
+		parts = append(parts, part)
+	}
+
+	return combineParts(license, expression, parts)
+}
@@ -0,0 +1,213 @@
+package ligen
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLicenseRenderCombined(t *testing.T) {
+	tests := []struct {
+		name              string
+		expression        string
+		expectedPaths     []string
+		expectedStatement string
+	}{
+		{
+			name:          "Pass-MIT-Or-Apache",
+			expression:    "MIT OR Apache-2.0",
+			expectedPaths: []string{"LICENSE", "LICENSE-MIT", "LICENSE-APACHE", "NOTICE"},
+			expectedStatement: `SPDX-License-Identifier: MIT OR Apache-2.0
+
+This project is licensed under either of the following licenses, at your option:
+
+  * MIT (LICENSE-MIT)
+  * Apache-2.0 (LICENSE-APACHE)
+`,
+		},
+		{
+			name:          "Pass-CollidingNames",
+			expression:    "BSD-2-Clause AND BSD-3-Clause",
+			expectedPaths: []string{"LICENSE", "LICENSE-BSD-2-CLAUSE", "LICENSE-BSD-3-CLAUSE"},
+			expectedStatement: `SPDX-License-Identifier: BSD-2-Clause AND BSD-3-Clause
+
+This project is licensed under all of the following licenses:
+
+  * BSD-2-Clause (LICENSE-BSD-2-CLAUSE)
+  * BSD-3-Clause (LICENSE-BSD-3-CLAUSE)
+`,
+		},
+		{
+			name:          "Pass-Nested",
+			expression:    "(MIT OR ISC) AND GPL-2.0-only WITH Classpath-exception-2.0",
+			expectedPaths: []string{"LICENSE", "LICENSE-MIT", "LICENSE-ISC", "LICENSE-GPL", "NOTICE"},
+			expectedStatement: `SPDX-License-Identifier: (MIT OR ISC) AND GPL-2.0-only WITH Classpath-exception-2.0
+
+This project is licensed under the license expression above. The text of each license is in its own file:
+
+  * MIT (LICENSE-MIT)
+  * ISC (LICENSE-ISC)
+  * GPL-2.0-only WITH Classpath-exception-2.0 (LICENSE-GPL)
+`,
+		},
+		{
+			name:          "Pass-IncorporatedLicense",
+			expression:    "LGPL-3.0-or-later OR MIT",
+			expectedPaths: []string{"LICENSE", "LICENSE-LGPL", "LICENSE-MIT", "LICENSE-GPL", "NOTICE"},
+			expectedStatement: `SPDX-License-Identifier: LGPL-3.0-or-later OR MIT
+
+This project is licensed under either of the following licenses, at your option:
+
+  * LGPL-3.0-or-later (LICENSE-LGPL)
+  * MIT (LICENSE-MIT)
+
+The licenses above incorporate the following licenses:
+
+  * GPL-3.0-or-later (LICENSE-GPL)
+`,
+		},
+		{
+			name:          "Pass-SingleWithException",
+			expression:    "GPL-2.0-or-later WITH Classpath-exception-2.0",
+			expectedPaths: []string{"LICENSE", "LICENSE-GPL", "NOTICE"},
+			expectedStatement: `SPDX-License-Identifier: GPL-2.0-or-later WITH Classpath-exception-2.0
+
+This project is licensed under the following license:
+
+  * GPL-2.0-or-later WITH Classpath-exception-2.0 (LICENSE-GPL)
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expression, err := ParseExpression(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			license, err := NewFromExpression("Ligen", "Peanut Butter", 2024, 0, expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			writeables, err := license.Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			paths := make([]string, len(writeables))
+			for idx, writeable := range writeables {
+				paths[idx] = writeable.Path
+			}
+
+			if !reflect.DeepEqual(tc.expectedPaths, paths) {
+				t.Errorf("Expected paths %v, got %v", tc.expectedPaths, paths)
+			}
+
+			if writeables[0].Content != tc.expectedStatement {
+				t.Errorf("Expected statement %q, got %q", tc.expectedStatement, writeables[0].Content)
+			}
+		})
+	}
+}
+
+func TestMergeNotices(t *testing.T) {
+	notices := []string{
+		"Ligen\nCopyright 2024 Peanut Butter",
+		"Ligen\nCopyright (C) 2024 Peanut Butter\n\nThis program is free software.\n",
+		"Ligen\nCopyright 2024 Peanut Butter\n\nThis program is free software.",
+	}
+
+	expected := "Ligen\nCopyright 2024 Peanut Butter\n\nThis program is free software.\n"
+
+	if merged := mergeNotices("Ligen", notices); merged != expected {
+		t.Errorf("Expected %q, got %q", expected, merged)
+	}
+}
+
+func TestLoadCombined(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		parameters Parameters
+	}{
+		{
+			name:       "Pass-MIT-Or-Apache",
+			expression: "MIT OR Apache-2.0",
+		},
+		{
+			name:       "Pass-GNULesser-And-ISC",
+			expression: "LGPL-3.0-or-later AND ISC",
+		},
+		{
+			name:       "Pass-Eclipse-Or-GPL",
+			expression: "EPL-2.0 OR GPL-2.0-or-later WITH Classpath-exception-2.0",
+			parameters: Parameters{SecondaryLicense: GNUGeneral2SecondaryLicense},
+		},
+		{
+			name:       "Pass-SingleWithException",
+			expression: "GPL-2.0-or-later WITH Classpath-exception-2.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// GIVEN
+			expression, err := ParseExpression(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			license, err := NewFromExpression("Ligen", "Peanut Butter", 2024, 0, expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = license.SetParameters(tc.parameters); err != nil {
+				t.Fatal(err)
+			}
+
+			writeables, err := license.Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			files := make(map[string]string, len(writeables))
+			for _, writeable := range writeables {
+				files[writeable.Path] = writeable.Content
+			}
+
+			licenseLoaders := make(map[LicenseType]loader)
+			for licenseType, path := range combinedLicensePaths(expression.LicenseTypes()) {
+				content := files[path]
+				licenseLoaders[licenseType] = func() (io.Reader, func() error, error) {
+					return strings.NewReader(content), func() error { return nil }, nil
+				}
+			}
+
+			noticeLoader := func() (io.Reader, func() error, error) {
+				return strings.NewReader(files["NOTICE"]), func() error { return nil }, nil
+			}
+
+			statement, err := ParseLicenseIdentifier(files["LICENSE"])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// WHEN
+			var loaded License
+			err = LoadCombined(&loaded, statement, licenseLoaders, noticeLoader)
+
+			// THEN
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(*license, loaded) {
+				t.Errorf("Expected %+v, got %+v", *license, loaded)
+			}
+		})
+	}
+}
@@ -0,0 +1,409 @@
+package ligen
+
+import (
+	"strings"
+)
+
+// DifferenceKind tells whether a difference from the canonical license text changes its meaning.
+type DifferenceKind int
+
+const (
+	// DIFFERENCE_COSMETIC means the texts only differ in ways the SPDX matching guidelines
+	// consider equivalent, e.g. case, quotes or the spelling of "licence"
+	DIFFERENCE_COSMETIC DifferenceKind = iota
+	// DIFFERENCE_SUBSTANTIVE means words were added, removed or replaced
+	DIFFERENCE_SUBSTANTIVE
+)
+
+// String returns the name of the difference kind.
+func (k DifferenceKind) String() string {
+	switch k {
+	case DIFFERENCE_COSMETIC:
+		return "COSMETIC"
+	case DIFFERENCE_SUBSTANTIVE:
+		return "SUBSTANTIVE"
+	default:
+		return ""
+	}
+}
+
+// Difference is a run of words that differ between the content and the canonical license text.
+type Difference struct {
+	// Line is the line of the content the difference starts on, 1-based
+	Line int
+	// Removed is the canonical text missing from the content
+	Removed string
+	// Added is the text the content has instead
+	Added string
+	Kind  DifferenceKind
+}
+
+// LicenseDiff lists the differences between a license file and the canonical text of its license.
+type LicenseDiff struct {
+	LicenseType LicenseType
+	Differences []Difference
+	// Modified is set when any difference is substantive, e.g. a rider adding a clause,
+	// so the license should be reviewed
+	Modified bool
+}
+
+// MAX_DIFF_EDITS is the maximum amount of words added and removed the diff looks for,
+// content that differs more is reported as a single substantive difference.
+// Detected licenses stay well below it, it only bounds the work for unrelated texts.
+const MAX_DIFF_EDITS = 1000
+
+// templateVariable stands in for the template actions of the canonical text, whatever
+// the content holds in its place is its value rather than a difference
+const templateVariable = "\x00"
+
+// diffToken is a word along with the line it's on
+type diffToken struct {
+	text string
+	line int
+}
+
+// isTemplateVariable reports whether the word holds a template variable
+func (t diffToken) isTemplateVariable() bool {
+	return strings.Contains(t.text, templateVariable)
+}
+
+// replaceTemplateVariables replaces every template action with templateVariable, actions that
+// open a block, e.g. "{{range .}}" or "{{if .SecondaryLicense}}", are replaced along with their body
+func replaceTemplateVariables(tmp string) string {
+	var replaced strings.Builder
+	depth, last := 0, 0
+
+	for _, loc := range templateActionPattern.FindAllStringIndex(tmp, -1) {
+		action := strings.TrimSpace(strings.Trim(tmp[loc[0]+2:loc[1]-2], "-"))
+
+		if depth == 0 {
+			replaced.WriteString(tmp[last:loc[0]])
+		}
+
+		switch {
+		case strings.HasPrefix(action, "if "), strings.HasPrefix(action, "with "), strings.HasPrefix(action, "range "):
+			if depth == 0 {
+				replaced.WriteString(templateVariable)
+			}
+			depth++
+		case action == "end":
+			depth--
+		case depth == 0:
+			replaced.WriteString(templateVariable)
+		}
+
+		last = loc[1]
+	}
+
+	replaced.WriteString(tmp[last:])
+
+	return replaced.String()
+}
+
+// diffTokens splits text into words, leaving out copyright lines along with the comment
+// and list markers at the start of lines, which the SPDX matching guidelines ignore
+func diffTokens(text string) []diffToken {
+	var tokens []diffToken
+
+	for idx, line := range strings.Split(text, "\n") {
+		line = removeListMarkers(removeCommentMarkers(line))
+		if copyrightLinePattern.MatchString(line) {
+			continue
+		}
+
+		for _, word := range strings.Fields(line) {
+			tokens = append(tokens, diffToken{text: word, line: idx + 1})
+		}
+	}
+
+	return tokens
+}
+
+// diffOp is a single step of the edit script turning the canonical words into the content's
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffRemove
+	diffAdd
+)
+
+// editScript finds the shortest edit script turning a into b with Myers' algorithm.
+// Returns false when it takes more than maxEdits words added and removed.
+func editScript(a, b []diffToken, maxEdits int) ([]diffOp, bool) {
+	n, m := len(a), len(b)
+	offset := maxEdits + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+
+	for d := 0; d <= maxEdits; d++ {
+		// Only diagonals -d..d can be reached with d edits, keep those for backtracking
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x].text == b[y].text {
+				x++
+				y++
+			}
+
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(trace, n, m), true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// backtrack walks the furthest reaching paths of editScript back from the end of both texts
+func backtrack(trace [][]int, n, m int) []diffOp {
+	var ops []diffOp
+	x, y := n, m
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		// trace[d] holds diagonals -d..d as they were before step d
+		at := func(k int) int { return trace[d][k+d] }
+
+		k := x - y
+
+		var previousK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			previousK = k + 1
+		} else {
+			previousK = k - 1
+		}
+
+		previousX := 0
+		if d > 0 {
+			previousX = at(previousK)
+		}
+		previousY := previousX - previousK
+
+		for x > previousX && y > previousY {
+			ops = append(ops, diffEqual)
+			x--
+			y--
+		}
+
+		if d == 0 {
+			break
+		}
+
+		if x == previousX {
+			ops = append(ops, diffAdd)
+		} else {
+			ops = append(ops, diffRemove)
+		}
+
+		x, y = previousX, previousY
+	}
+
+	for x > 0 && y > 0 {
+		ops = append(ops, diffEqual)
+		x--
+		y--
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+
+	return ops
+}
+
+// startsLine reports whether the word at idx is the first one on its line
+func startsLine(tokens []diffToken, idx int) bool {
+	return idx == 0 || tokens[idx-1].line != tokens[idx].line
+}
+
+// slideToLineStart moves runs of only added or only removed words back to the start of a line
+// where the edit script is just as short that way, so a clause added at the start of a paragraph
+// reads "The Software may not ..." rather than "Software may not ... The".
+func slideToLineStart(ops []diffOp, a, b []diffToken) {
+	x, y := 0, 0
+
+	for i := 0; i < len(ops); {
+		if ops[i] == diffEqual {
+			x, y = x+1, y+1
+			i++
+			continue
+		}
+
+		start, startX, startY := i, x, y
+		for i < len(ops) && ops[i] != diffEqual {
+			if ops[i] == diffRemove {
+				x++
+			} else {
+				y++
+			}
+			i++
+		}
+
+		op, tokens, from, to := diffAdd, b, startY, y
+		if x != startX {
+			op, tokens, from, to = diffRemove, a, startX, x
+		}
+
+		// Runs that both add and remove words can't slide
+		if x != startX && y != startY {
+			continue
+		}
+
+		shift := 0
+		for start-shift > 0 && ops[start-shift-1] == diffEqual && !startsLine(tokens, from-shift) &&
+			tokens[from-shift-1].text == tokens[to-shift-1].text {
+			shift++
+		}
+
+		if shift == 0 || !startsLine(tokens, from-shift) {
+			continue
+		}
+
+		for idx := start - shift; idx < i; idx++ {
+			ops[idx] = diffEqual
+			if idx < i-shift {
+				ops[idx] = op
+			}
+		}
+	}
+}
+
+// joinTokens joins the words of a difference back into text
+func joinTokens(tokens []diffToken) string {
+	words := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if word := strings.ReplaceAll(token.text, templateVariable, ""); word != "" {
+			words = append(words, word)
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// isTemplateValue reports whether the added words are the value of the template variables
+// they replace, values are kept to a single line so clauses added next to a variable still show
+func isTemplateValue(removed, added []diffToken) bool {
+	if len(removed) == 0 {
+		return false
+	}
+
+	for _, token := range removed {
+		if !token.isTemplateVariable() {
+			return false
+		}
+	}
+
+	return len(added) == 0 || added[0].line == added[len(added)-1].line
+}
+
+// newDifference classifies the words removed from the canonical text and added by the content
+func newDifference(removed, added []diffToken, line int) Difference {
+	difference := Difference{
+		Line:    line,
+		Removed: joinTokens(removed),
+		Added:   joinTokens(added),
+		Kind:    DIFFERENCE_SUBSTANTIVE,
+	}
+
+	if len(added) > 0 {
+		difference.Line = added[0].line
+	}
+
+	if NormalizeLicenseText(difference.Removed) == NormalizeLicenseText(difference.Added) {
+		difference.Kind = DIFFERENCE_COSMETIC
+	}
+
+	return difference
+}
+
+// lineAt returns the line of the token at idx, or of the last token when idx is past the end
+func lineAt(tokens []diffToken, idx int) int {
+	if len(tokens) == 0 {
+		return 1
+	}
+
+	return tokens[min(idx, len(tokens)-1)].line
+}
+
+// Diff compares the content word by word against the canonical text of the license, ignoring
+// template variables, copyright lines and wrapping. Differences the SPDX matching guidelines
+// consider equivalent are cosmetic, every other one is substantive and marks the license modified.
+func (lt LicenseType) Diff(content string) (LicenseDiff, error) {
+	tmp, err := lt.Template()
+	if err != nil {
+		return LicenseDiff{}, err
+	}
+
+	canonical := diffTokens(replaceTemplateVariables(tmp))
+	tokens := diffTokens(content)
+
+	diff := LicenseDiff{LicenseType: lt}
+
+	ops, ok := editScript(canonical, tokens, MAX_DIFF_EDITS)
+	if !ok {
+		diff.Differences = []Difference{newDifference(canonical, tokens, 1)}
+		diff.Modified = true
+
+		return diff, nil
+	}
+
+	slideToLineStart(ops, canonical, tokens)
+
+	var removed, added []diffToken
+	x, y := 0, 0
+
+	flush := func() {
+		if len(removed) == 0 && len(added) == 0 {
+			return
+		}
+
+		if !isTemplateValue(removed, added) {
+			difference := newDifference(removed, added, lineAt(tokens, y))
+			diff.Differences = append(diff.Differences, difference)
+			diff.Modified = diff.Modified || difference.Kind == DIFFERENCE_SUBSTANTIVE
+		}
+
+		removed, added = nil, nil
+	}
+
+	for _, op := range ops {
+		switch op {
+		case diffEqual:
+			flush()
+			x++
+			y++
+		case diffRemove:
+			removed = append(removed, canonical[x])
+			x++
+		case diffAdd:
+			added = append(added, tokens[y])
+			y++
+		}
+	}
+
+	flush()
+
+	return diff, nil
+}
+
+// DiffLicense detects the license of the content and compares it against the canonical text
+// of the license, see LicenseType.Diff. The threshold is passed on to Match.
+// Returns DetectionFailedError when no license is detected.
+func DiffLicense(content string, threshold float64) (LicenseDiff, error) {
+	licenseType, err := Match(content, threshold)
+	if err != nil {
+		return LicenseDiff{}, err
+	}
+
+	return licenseType.Diff(content)
+}
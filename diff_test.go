@@ -0,0 +1,131 @@
+package ligen
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLicenseTypeDiff(t *testing.T) {
+	var buf bytes.Buffer
+	mit, err := buildInput(MITGenerator, "Ligen", "Max Moon", 2025, 0, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf.Reset()
+	bsd, err := buildInput(BSD4ClauseGenerator, "Ligen", "Peanut Butter Inc.", 2025, 0, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		licenseType LicenseType
+		content     string
+		expected    LicenseDiff
+	}{
+		{
+			name:        "Pass-Unmodified",
+			licenseType: MIT,
+			content:     mit,
+			expected:    LicenseDiff{LicenseType: MIT},
+		},
+		{
+			name:        "Pass-TemplateVariables",
+			licenseType: BSD_4_CLAUSE,
+			content:     bsd,
+			expected:    LicenseDiff{LicenseType: BSD_4_CLAUSE},
+		},
+		{
+			name:        "Pass-Cosmetic",
+			licenseType: MIT,
+			content:     strings.NewReplacer(`"AS IS"`, "“AS IS”", "MIT License", "MIT Licence").Replace(rewrap(mit, 60)),
+			expected: LicenseDiff{
+				LicenseType: MIT,
+				Differences: []Difference{
+					{Line: 1, Removed: "License", Added: "Licence", Kind: DIFFERENCE_COSMETIC},
+					{Line: 18, Removed: `"AS IS",`, Added: "“AS IS”,", Kind: DIFFERENCE_COSMETIC},
+				},
+			},
+		},
+		{
+			name:        "Pass-AddedClause",
+			licenseType: MIT,
+			content:     strings.Replace(mit, "The above copyright", "The Software may not be used for commercial purposes.\n\nThe above copyright", 1),
+			expected: LicenseDiff{
+				LicenseType: MIT,
+				Differences: []Difference{
+					{Line: 12, Added: "The Software may not be used for commercial purposes.", Kind: DIFFERENCE_SUBSTANTIVE},
+				},
+				Modified: true,
+			},
+		},
+		{
+			name:        "Pass-RemovedClause",
+			licenseType: MIT,
+			content:     strings.Replace(mit, "The above copyright notice and this permission notice shall be included in all\ncopies or substantial portions of the Software.\n\n", "", 1),
+			expected: LicenseDiff{
+				LicenseType: MIT,
+				Differences: []Difference{
+					{Line: 12, Removed: "The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.", Kind: DIFFERENCE_SUBSTANTIVE},
+				},
+				Modified: true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, err := tc.licenseType.Diff(tc.content)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, diff) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestDiffLicense(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		modified     bool
+		errorMessage string
+	}{
+		{
+			name: "Pass-Modified",
+			content: func() string {
+				var buf bytes.Buffer
+				mit, _ := buildInput(MITGenerator, "Ligen", "Max Moon", 2025, 0, &buf)
+
+				return mit + "\nThe Software may not be used for commercial purposes.\n"
+			}(),
+			modified: true,
+		},
+		{
+			name:         "Fail-NoMatchFound",
+			content:      "The dog likes to jump and play.",
+			errorMessage: DetectionFailedError.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, err := DiffLicense(tc.content, 0.90)
+
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			if diff.LicenseType != MIT || diff.Modified != tc.modified {
+				t.Errorf("Expected MIT modified %t, got %+v", tc.modified, diff)
+			}
+		})
+	}
+}
@@ -84,6 +84,23 @@ func ReadMe() (doyoucompute.Document, error) {
 	featuresList.Append("Manage copyright years and holder information")
 	featuresList.Append("Parse existing license files")
 	featuresList.Append("Template-based license generation")
+	featuresList.Append("SPDX license identifiers, including deprecated ones")
+	featuresList.Append("Parse, validate and normalize SPDX license expressions")
+	featuresList.Append("Dual and multi-license projects, e.g. LICENSE-MIT and LICENSE-APACHE")
+	featuresList.Append("REUSE layout with LICENSES/ and REUSE.toml, and a lint check")
+	featuresList.Append("Add and update license headers in source files")
+	featuresList.Append("Check source file headers for missing, stale, wrong or malformed notices")
+	featuresList.Append("Bump copyright years in LICENSE, NOTICE and source headers at once")
+	featuresList.Append("Derive copyright years and holders from git history")
+	featuresList.Append("Multiple copyright holders per license")
+	featuresList.Append("Copyright year lists and open ranges, e.g. \"2018, 2020-2022\" or \"2019-present\"")
+	featuresList.Append("Lenient copyright parsing: ©, (c), holder emails, \"and contributors\" and \"All rights reserved.\"")
+	featuresList.Append("Configurable validation policy: clock, how far back copyrights can start, name length and future end years")
+	featuresList.Append("License detection normalizes text following the SPDX matching guidelines")
+	featuresList.Append("Ranked license detection listing every candidate with its score, detection method and matched lines")
+	featuresList.Append("Diff a license against its canonical text, flagging substantive changes like added clauses")
+	featuresList.Append("Locate every license in a bundled LICENSE file, with its byte and line span and nearby copyrights")
+	featuresList.Append("Reusable, concurrency safe Matcher with license fingerprints computed once for scanning many files")
 
 	// Supported Licenses
 	licensesSection := featuresSection.CreateSection("Supported Licenses")
@@ -93,7 +110,15 @@ func ReadMe() (doyoucompute.Document, error) {
 	licensesList.Append("Mozilla Public License 2.0")
 	licensesList.Append("Boost Software License 1.0")
 	licensesList.Append("The Unlicense")
-	licensesList.Append("GNU Lesser General Public License 3.0")
+	licensesList.Append("GNU Lesser General Public License 3.0, only and or later")
+	licensesList.Append("GNU General Public License 2.0 and 3.0, only and or later")
+	licensesList.Append("GNU Affero General Public License 3.0, only and or later")
+	licensesList.Append("BSD Zero Clause, 2-Clause, 3-Clause and 4-Clause")
+	licensesList.Append("ISC, zlib, PostgreSQL, Blue Oak Model 1.0.0 and Unicode 3.0")
+	licensesList.Append("Creative Commons CC0 1.0, Attribution 4.0 and Attribution-ShareAlike 4.0")
+	licensesList.Append("Eclipse Public License 2.0, with an optional Secondary License")
+	licensesList.Append("Business Source License 1.1, Elastic License 2.0 and PolyForm Noncommercial 1.0.0")
+	licensesList.Append("Proprietary \"All rights reserved\", with an optional confidentiality clause and contact email")
 
 	// Quickstart
 	quickstart, err := quickStartSection()
@@ -0,0 +1,487 @@
+package ligen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	EmptyExpressionError   = errors.New("expression must not be empty")
+	InvalidExpressionError = errors.New("invalid license expression")
+	UnknownExceptionError  = errors.New("unknown license exception")
+)
+
+// spdxExceptionIDs is the SPDX license exception list, used to validate the right hand side of WITH
+var spdxExceptionIDs = []string{
+	"389-exception",
+	"Asterisk-exception",
+	"Autoconf-exception-2.0",
+	"Autoconf-exception-3.0",
+	"Autoconf-exception-generic",
+	"Autoconf-exception-generic-3.0",
+	"Autoconf-exception-macro",
+	"Bison-exception-1.24",
+	"Bison-exception-2.2",
+	"Bootloader-exception",
+	"Classpath-exception-2.0",
+	"CLISP-exception-2.0",
+	"cryptsetup-OpenSSL-exception",
+	"DigiRule-FOSS-exception",
+	"eCos-exception-2.0",
+	"Fawkes-Runtime-exception",
+	"FLTK-exception",
+	"fmt-exception",
+	"Font-exception-2.0",
+	"freertos-exception-2.0",
+	"GCC-exception-2.0",
+	"GCC-exception-2.0-note",
+	"GCC-exception-3.1",
+	"Gmsh-exception",
+	"GNAT-exception",
+	"GNOME-examples-exception",
+	"GNU-compiler-exception",
+	"gnu-javamail-exception",
+	"GPL-3.0-interface-exception",
+	"GPL-3.0-linking-exception",
+	"GPL-3.0-linking-source-exception",
+	"GPL-CC-1.0",
+	"GStreamer-exception-2005",
+	"GStreamer-exception-2008",
+	"i2p-gpl-java-exception",
+	"KiCad-libraries-exception",
+	"LGPL-3.0-linking-exception",
+	"libpri-OpenH323-exception",
+	"Libtool-exception",
+	"Linux-syscall-note",
+	"LLGPL",
+	"LLVM-exception",
+	"LZMA-exception",
+	"mif-exception",
+	"OCaml-LGPL-linking-exception",
+	"OCCT-exception-1.0",
+	"OpenJDK-assembly-exception-1.0",
+	"openvpn-openssl-exception",
+	"PS-or-PDF-font-exception-20170817",
+	"QPL-1.0-INRIA-2004-exception",
+	"Qt-GPL-exception-1.0",
+	"Qt-LGPL-exception-1.1",
+	"Qwt-exception-1.0",
+	"SANE-exception",
+	"SHL-2.0",
+	"SHL-2.1",
+	"stunnel-exception",
+	"SWI-exception",
+	"Swift-exception",
+	"Texinfo-exception",
+	"u-boot-exception-2.0",
+	"UBDL-exception",
+	"Universal-FOSS-exception-1.0",
+	"vsftpd-openssl-exception",
+	"WxWindows-exception-3.1",
+	"x11vnc-openssl-exception",
+}
+
+// exceptionID returns the canonical form of an SPDX license exception identifier.
+// The input is case-insensitive.
+func exceptionID(id string) (string, bool) {
+	for _, known := range spdxExceptionIDs {
+		if strings.EqualFold(known, id) {
+			return known, true
+		}
+	}
+
+	return "", false
+}
+
+// ExpressionOperator is the kind of a node in a license expression.
+type ExpressionOperator int
+
+const (
+	// SIMPLE_EXPRESSION is a single license, optionally with an exception
+	SIMPLE_EXPRESSION ExpressionOperator = iota + 1
+	// AND_EXPRESSION requires all of its operands to be complied with
+	AND_EXPRESSION
+	// OR_EXPRESSION lets the recipient choose one of its operands
+	OR_EXPRESSION
+)
+
+// String returns the SPDX keyword for the operator.
+func (op ExpressionOperator) String() string {
+	switch op {
+	case AND_EXPRESSION:
+		return "AND"
+	case OR_EXPRESSION:
+		return "OR"
+	default:
+		return ""
+	}
+}
+
+// Expression is a parsed SPDX license expression.
+// Simple expressions hold a license type and an optional exception,
+// AND and OR expressions hold their operands in the order they were written.
+//
+// The parser lives in this package rather than its own: expressions are made of LicenseType values,
+// and License.SetExpression and the REUSE and header code take an Expression in turn, so a separate
+// package would import ligen while ligen imports it.
+type Expression struct {
+	Operator    ExpressionOperator
+	LicenseType LicenseType
+	Exception   string
+	Operands    []Expression
+}
+
+// ParseExpression parses an SPDX license expression such as "MIT OR Apache-2.0" or
+// "GPL-2.0-or-later WITH Classpath-exception-2.0".
+// Identifiers and operators are case-insensitive, WITH binds tighter than AND, which binds tighter than OR.
+// Every license must be a supported license type and every exception must be on the SPDX exception list.
+func ParseExpression(expression string) (Expression, error) {
+	p := expressionParser{tokens: tokenizeExpression(expression)}
+	if len(p.tokens) == 0 {
+		return Expression{}, EmptyExpressionError
+	}
+
+	parsed, err := p.parseOr()
+	if err != nil {
+		return Expression{}, err
+	}
+
+	if tok, ok := p.peek(); ok {
+		return Expression{}, fmt.Errorf("%w: unexpected %q at offset %d", InvalidExpressionError, tok.value, tok.offset)
+	}
+
+	return parsed, nil
+}
+
+// ValidateExpression checks that an SPDX license expression is well formed
+// and only references known licenses and exceptions.
+func ValidateExpression(expression string) error {
+	_, err := ParseExpression(expression)
+	return err
+}
+
+// NormalizeExpression parses an SPDX license expression and returns its normalized form.
+func NormalizeExpression(expression string) (string, error) {
+	parsed, err := ParseExpression(expression)
+	if err != nil {
+		return "", err
+	}
+
+	return parsed.Normalize().String(), nil
+}
+
+// Validate checks an expression built by hand, the same way ParseExpression checks parsed ones.
+func (e Expression) Validate() error {
+	switch e.Operator {
+	case SIMPLE_EXPRESSION:
+		if e.LicenseType.SPDXID() == "" {
+			return InvalidLicenseType
+		}
+
+		if e.Exception != "" {
+			if _, ok := exceptionID(e.Exception); !ok {
+				return fmt.Errorf("%w: %s", UnknownExceptionError, e.Exception)
+			}
+		}
+
+		return nil
+	case AND_EXPRESSION, OR_EXPRESSION:
+		if len(e.Operands) < 2 {
+			return fmt.Errorf("%w: %s requires at least two operands", InvalidExpressionError, e.Operator.String())
+		}
+
+		for _, operand := range e.Operands {
+			if err := operand.Validate(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown operator", InvalidExpressionError)
+	}
+}
+
+// Normalize returns an equivalent expression with canonical exception identifiers,
+// nested operations of the same kind flattened and repeated operands removed.
+// The order of operands is kept, it often signals the preferred license.
+func (e Expression) Normalize() Expression {
+	if e.Operator == SIMPLE_EXPRESSION {
+		if canonical, ok := exceptionID(e.Exception); ok {
+			e.Exception = canonical
+		}
+
+		return e
+	}
+
+	var operands []Expression
+	seen := make(map[string]bool)
+	add := func(operand Expression) {
+		key := operand.String()
+		if seen[key] {
+			return
+		}
+
+		seen[key] = true
+		operands = append(operands, operand)
+	}
+
+	for _, operand := range e.Operands {
+		normalized := operand.Normalize()
+		if normalized.Operator != e.Operator {
+			add(normalized)
+			continue
+		}
+
+		for _, nested := range normalized.Operands {
+			add(nested)
+		}
+	}
+
+	if len(operands) == 1 {
+		return operands[0]
+	}
+
+	return Expression{Operator: e.Operator, Operands: operands}
+}
+
+// String renders the expression using SPDX identifiers, upper case operators
+// and only the parentheses needed to keep its meaning.
+func (e Expression) String() string {
+	if e.Operator == SIMPLE_EXPRESSION {
+		if e.Exception == "" {
+			return e.LicenseType.SPDXID()
+		}
+
+		return e.LicenseType.SPDXID() + " WITH " + e.Exception
+	}
+
+	parts := make([]string, len(e.Operands))
+	for idx, operand := range e.Operands {
+		parts[idx] = operand.String()
+
+		// OR binds loosest, so it's the only operation that needs grouping inside another
+		if e.Operator == AND_EXPRESSION && operand.Operator == OR_EXPRESSION {
+			parts[idx] = "(" + parts[idx] + ")"
+		}
+	}
+
+	return strings.Join(parts, " "+e.Operator.String()+" ")
+}
+
+// LicenseTypes returns every license type referenced by the expression, in order of first appearance.
+func (e Expression) LicenseTypes() []LicenseType {
+	var licenseTypes []LicenseType
+	seen := make(map[LicenseType]bool)
+
+	var walk func(node Expression)
+	walk = func(node Expression) {
+		if node.Operator == SIMPLE_EXPRESSION {
+			if !seen[node.LicenseType] {
+				seen[node.LicenseType] = true
+				licenseTypes = append(licenseTypes, node.LicenseType)
+			}
+			return
+		}
+
+		for _, operand := range node.Operands {
+			walk(operand)
+		}
+	}
+	walk(e)
+
+	return licenseTypes
+}
+
+// exceptions returns the exception each license type is used with. A license type
+// written with several exceptions, or with and without one, keeps the first exception.
+func (e Expression) exceptions() map[LicenseType]string {
+	exceptions := make(map[LicenseType]string)
+
+	var walk func(node Expression)
+	walk = func(node Expression) {
+		if node.Operator == SIMPLE_EXPRESSION {
+			if _, ok := exceptions[node.LicenseType]; !ok && node.Exception != "" {
+				exceptions[node.LicenseType] = node.Exception
+			}
+			return
+		}
+
+		for _, operand := range node.Operands {
+			walk(operand)
+		}
+	}
+	walk(e)
+
+	return exceptions
+}
+
+// requiresCombinedStatement reports whether the license files alone can't express the expression,
+// either because it covers several licenses or because its license is used with an exception.
+func (e Expression) requiresCombinedStatement() bool {
+	return e.Operator != SIMPLE_EXPRESSION || e.Exception != ""
+}
+
+type expressionToken struct {
+	value  string
+	offset int
+}
+
+// tokenizeExpression splits an expression into parentheses and words
+func tokenizeExpression(expression string) []expressionToken {
+	var tokens []expressionToken
+
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			tokens = append(tokens, expressionToken{value: expression[start:end], offset: start})
+			start = -1
+		}
+	}
+
+	for idx, r := range expression {
+		switch {
+		case r == '(' || r == ')':
+			flush(idx)
+			tokens = append(tokens, expressionToken{value: string(r), offset: idx})
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			flush(idx)
+		default:
+			if start < 0 {
+				start = idx
+			}
+		}
+	}
+	flush(len(expression))
+
+	return tokens
+}
+
+type expressionParser struct {
+	tokens   []expressionToken
+	position int
+}
+
+func (p *expressionParser) peek() (expressionToken, bool) {
+	if p.position >= len(p.tokens) {
+		return expressionToken{}, false
+	}
+
+	return p.tokens[p.position], true
+}
+
+func (p *expressionParser) next() (expressionToken, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return expressionToken{}, fmt.Errorf("%w: unexpected end of expression", InvalidExpressionError)
+	}
+
+	p.position++
+	return tok, nil
+}
+
+// acceptKeyword consumes the next token if it's the given operator keyword
+func (p *expressionParser) acceptKeyword(keyword string) bool {
+	tok, ok := p.peek()
+	if !ok || !strings.EqualFold(tok.value, keyword) {
+		return false
+	}
+
+	p.position++
+	return true
+}
+
+func (p *expressionParser) parseOr() (Expression, error) {
+	return p.parseOperation(OR_EXPRESSION, p.parseAnd)
+}
+
+func (p *expressionParser) parseAnd() (Expression, error) {
+	return p.parseOperation(AND_EXPRESSION, p.parsePrimary)
+}
+
+// parseOperation parses one or more operands joined by the operator's keyword
+func (p *expressionParser) parseOperation(operator ExpressionOperator, operand func() (Expression, error)) (Expression, error) {
+	first, err := operand()
+	if err != nil {
+		return Expression{}, err
+	}
+
+	operands := []Expression{first}
+	for p.acceptKeyword(operator.String()) {
+		next, err := operand()
+		if err != nil {
+			return Expression{}, err
+		}
+
+		operands = append(operands, next)
+	}
+
+	if len(operands) == 1 {
+		return first, nil
+	}
+
+	return Expression{Operator: operator, Operands: operands}, nil
+}
+
+func (p *expressionParser) parsePrimary() (Expression, error) {
+	tok, err := p.next()
+	if err != nil {
+		return Expression{}, err
+	}
+
+	if tok.value == "(" {
+		inner, err := p.parseOr()
+		if err != nil {
+			return Expression{}, err
+		}
+
+		closing, err := p.next()
+		if err != nil {
+			return Expression{}, err
+		}
+
+		if closing.value != ")" {
+			return Expression{}, fmt.Errorf("%w: expected \")\" at offset %d", InvalidExpressionError, closing.offset)
+		}
+
+		return inner, nil
+	}
+
+	if isExpressionKeyword(tok.value) || tok.value == ")" {
+		return Expression{}, fmt.Errorf("%w: unexpected %q at offset %d", InvalidExpressionError, tok.value, tok.offset)
+	}
+
+	licenseType, err := LicenseTypeFromSPDXID(tok.value)
+	if err != nil {
+		return Expression{}, fmt.Errorf("%w: %s", InvalidLicenseType, tok.value)
+	}
+
+	simple := Expression{Operator: SIMPLE_EXPRESSION, LicenseType: licenseType}
+	if !p.acceptKeyword("WITH") {
+		return simple, nil
+	}
+
+	exception, err := p.next()
+	if err != nil {
+		return Expression{}, err
+	}
+
+	canonical, ok := exceptionID(exception.value)
+	if !ok {
+		return Expression{}, fmt.Errorf("%w: %s", UnknownExceptionError, exception.value)
+	}
+	simple.Exception = canonical
+
+	return simple, nil
+}
+
+func isExpressionKeyword(value string) bool {
+	for _, keyword := range []string{"AND", "OR", "WITH"} {
+		if strings.EqualFold(value, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
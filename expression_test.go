@@ -0,0 +1,242 @@
+package ligen
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expected     Expression
+		errorMessage string
+	}{
+		{
+			name:  "Passing-Single",
+			input: "MIT",
+			expected: Expression{
+				Operator:    SIMPLE_EXPRESSION,
+				LicenseType: MIT,
+			},
+			errorMessage: "",
+		},
+		{
+			name:  "Passing-Or",
+			input: "MIT OR Apache-2.0",
+			expected: Expression{
+				Operator: OR_EXPRESSION,
+				Operands: []Expression{
+					{Operator: SIMPLE_EXPRESSION, LicenseType: MIT},
+					{Operator: SIMPLE_EXPRESSION, LicenseType: APACHE_2_0},
+				},
+			},
+			errorMessage: "",
+		},
+		{
+			name:  "Passing-With",
+			input: "GPL-2.0-or-later WITH Classpath-exception-2.0",
+			expected: Expression{
+				Operator:    SIMPLE_EXPRESSION,
+				LicenseType: GNU_GENERAL_2_0_OR_LATER,
+				Exception:   "Classpath-exception-2.0",
+			},
+			errorMessage: "",
+		},
+		{
+			name:  "Passing-AndBindsTighterThanOr",
+			input: "MIT OR Apache-2.0 AND BSD-3-Clause",
+			expected: Expression{
+				Operator: OR_EXPRESSION,
+				Operands: []Expression{
+					{Operator: SIMPLE_EXPRESSION, LicenseType: MIT},
+					{
+						Operator: AND_EXPRESSION,
+						Operands: []Expression{
+							{Operator: SIMPLE_EXPRESSION, LicenseType: APACHE_2_0},
+							{Operator: SIMPLE_EXPRESSION, LicenseType: BSD_3_CLAUSE},
+						},
+					},
+				},
+			},
+			errorMessage: "",
+		},
+		{
+			name:  "Passing-Parentheses",
+			input: "(mit or apache-2.0) and isc",
+			expected: Expression{
+				Operator: AND_EXPRESSION,
+				Operands: []Expression{
+					{
+						Operator: OR_EXPRESSION,
+						Operands: []Expression{
+							{Operator: SIMPLE_EXPRESSION, LicenseType: MIT},
+							{Operator: SIMPLE_EXPRESSION, LicenseType: APACHE_2_0},
+						},
+					},
+					{Operator: SIMPLE_EXPRESSION, LicenseType: ISC},
+				},
+			},
+			errorMessage: "",
+		},
+		{
+			name:         "Failing-Empty",
+			input:        "  ",
+			expected:     Expression{},
+			errorMessage: EmptyExpressionError.Error(),
+		},
+		{
+			name:         "Failing-UnknownLicense",
+			input:        "MIT OR WTFPL",
+			expected:     Expression{},
+			errorMessage: "invalid license type: WTFPL",
+		},
+		{
+			name:         "Failing-UnknownException",
+			input:        "GPL-2.0-only WITH Made-Up-exception",
+			expected:     Expression{},
+			errorMessage: "unknown license exception: Made-Up-exception",
+		},
+		{
+			name:         "Failing-DanglingOperator",
+			input:        "MIT OR",
+			expected:     Expression{},
+			errorMessage: "invalid license expression: unexpected end of expression",
+		},
+		{
+			name:         "Failing-UnclosedParenthesis",
+			input:        "(MIT OR ISC",
+			expected:     Expression{},
+			errorMessage: "invalid license expression: unexpected end of expression",
+		},
+		{
+			name:         "Failing-MissingOperator",
+			input:        "MIT ISC",
+			expected:     Expression{},
+			errorMessage: "invalid license expression: unexpected \"ISC\" at offset 4",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expression, err := ParseExpression(tc.input)
+
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			if !reflect.DeepEqual(tc.expected, expression) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, expression)
+			}
+		})
+	}
+}
+
+func TestNormalizeExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Passing-CanonicalCase",
+			input:    "mit or apache-2.0",
+			expected: "MIT OR Apache-2.0",
+		},
+		{
+			name:     "Passing-DeprecatedID",
+			input:    "GPL-2.0+ WITH classpath-exception-2.0",
+			expected: "GPL-2.0-or-later WITH Classpath-exception-2.0",
+		},
+		{
+			name:     "Passing-RedundantParentheses",
+			input:    "((MIT) OR (Apache-2.0 AND ISC))",
+			expected: "MIT OR Apache-2.0 AND ISC",
+		},
+		{
+			name:     "Passing-Flattened",
+			input:    "(MIT OR ISC) OR (Apache-2.0 OR MIT)",
+			expected: "MIT OR ISC OR Apache-2.0",
+		},
+		{
+			name:     "Passing-KeepsNeededParentheses",
+			input:    "(MIT OR Apache-2.0) AND ISC",
+			expected: "(MIT OR Apache-2.0) AND ISC",
+		},
+		{
+			name:     "Passing-DuplicateCollapses",
+			input:    "MIT AND MIT",
+			expected: "MIT",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			normalized, err := NormalizeExpression(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if normalized != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, normalized)
+			}
+		})
+	}
+}
+
+func TestExpressionValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        Expression
+		errorMessage string
+	}{
+		{
+			name: "Passing",
+			input: Expression{
+				Operator: OR_EXPRESSION,
+				Operands: []Expression{
+					{Operator: SIMPLE_EXPRESSION, LicenseType: MIT},
+					{Operator: SIMPLE_EXPRESSION, LicenseType: GNU_GENERAL_3_0_ONLY, Exception: "GCC-exception-3.1"},
+				},
+			},
+			errorMessage: "",
+		},
+		{
+			name:         "Failing-UnknownLicenseType",
+			input:        Expression{Operator: SIMPLE_EXPRESSION, LicenseType: LicenseType(-1)},
+			errorMessage: InvalidLicenseType.Error(),
+		},
+		{
+			name: "Failing-SingleOperand",
+			input: Expression{
+				Operator: AND_EXPRESSION,
+				Operands: []Expression{{Operator: SIMPLE_EXPRESSION, LicenseType: MIT}},
+			},
+			errorMessage: "invalid license expression: AND requires at least two operands",
+		},
+		{
+			name:         "Failing-UnknownException",
+			input:        Expression{Operator: SIMPLE_EXPRESSION, LicenseType: MIT, Exception: "Nope-exception"},
+			errorMessage: "unknown license exception: Nope-exception",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			checkError(tc.errorMessage, tc.input.Validate(), t)
+		})
+	}
+}
+
+func TestExpressionLicenseTypes(t *testing.T) {
+	expression, err := ParseExpression("(MIT OR Apache-2.0) AND (MIT OR GPL-3.0-only WITH GCC-exception-3.1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []LicenseType{MIT, APACHE_2_0, GNU_GENERAL_3_0_ONLY}
+	if licenseTypes := expression.LicenseTypes(); !reflect.DeepEqual(expected, licenseTypes) {
+		t.Errorf("Expected %v, got %v", expected, licenseTypes)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 )
 
 // Write writes the content of a Writeable to the provided writer.
@@ -50,6 +53,8 @@ type loader func() (io.Reader, func() error, error)
 
 // Load loads license information from the provided loaders and populates the License.
 // The licenseLoader provides the license file content, and noticeLoader provides the NOTICE file content if required by the license type.
+// Lines that look like a copyright statement but can't be parsed are kept on the License instead of being dropped,
+// see Service.GetUnparsedCopyrights.
 func Load(license *License, licenseLoader loader, noticeLoader loader) error {
 	licenseReader, close, err := licenseLoader()
 	if err != nil {
@@ -62,7 +67,7 @@ func Load(license *License, licenseLoader loader, noticeLoader loader) error {
 		return err
 	}
 
-	var projectName string
+	var projectName, notice string
 	contentContainingCopyright := licenseResult.content
 
 	if licenseResult.licenseType.RequiresNotice() {
@@ -72,12 +77,13 @@ func Load(license *License, licenseLoader loader, noticeLoader loader) error {
 		}
 		defer close()
 
-		notice, err := loadNotice(noticeReader)
+		notice, err = loadNotice(noticeReader)
 		if err != nil {
 			return err
 		}
 
 		contentContainingCopyright = notice
+		licenseResult.licenseType = resolveVersionChoice(licenseResult.licenseType, notice)
 
 		projectName, err = ParseProjectNameFromNotice(notice)
 		if err != nil {
@@ -85,16 +91,26 @@ func Load(license *License, licenseLoader loader, noticeLoader loader) error {
 		}
 	}
 
-	var copyright Copyright
+	var copyrights Copyrights
+	var unparsed []UnparsedCopyright
 	if licenseResult.licenseType.RequiresCopyright() {
-		copyright, err = ParseDocForCopyright(contentContainingCopyright)
+		copyrights, err = ParseDocForCopyrights(contentContainingCopyright)
 		if err != nil {
 			return err
 		}
+
+		_, unparsed = ScanCopyrights(contentContainingCopyright)
+	}
+
+	parameters, err := parseParameters(licenseResult.licenseType, licenseResult.content, notice)
+	if err != nil {
+		return err
 	}
 
 	license.projectName = projectName
-	license.copyright = copyright
+	license.copyrights = copyrights
+	license.parameters = parameters
+	license.unparsedCopyrights = unparsed
 	license.SetLicenseType(licenseResult.licenseType)
 
 	return nil
@@ -113,20 +129,161 @@ func loadFile(path string) (io.Reader, func() error, error) {
 	return f, close, nil
 }
 
+// loadFiles opens each of the paths and reads them back to back as a single document.
+func loadFiles(paths ...string) (io.Reader, func() error, error) {
+	readers := make([]io.Reader, 0, len(paths))
+	closers := make([]func() error, 0, len(paths))
+
+	closeAll := func() error {
+		errs := make([]error, 0, len(closers))
+		for _, close := range closers {
+			errs = append(errs, close())
+		}
+
+		return errors.Join(errs...)
+	}
+
+	for _, path := range paths {
+		reader, close, err := loadFile(path)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+
+		readers = append(readers, reader)
+		closers = append(closers, close)
+	}
+
+	return io.MultiReader(readers...), closeAll, nil
+}
+
+// licenseFilePaths returns the files that make up the license at path.
+// The GNU Lesser layout splits its text across COPYING and COPYING.LESSER,
+// so when both are present either one brings in the other.
+func licenseFilePaths(path string) []string {
+	dir, name := filepath.Split(path)
+
+	var sibling string
+	switch name {
+	case "COPYING":
+		sibling = "COPYING.LESSER"
+	case "COPYING.LESSER":
+		sibling = "COPYING"
+	default:
+		return []string{path}
+	}
+
+	siblingPath := filepath.Join(dir, sibling)
+	if _, err := os.Stat(siblingPath); err != nil {
+		return []string{path}
+	}
+
+	return []string{path, siblingPath}
+}
+
+// combinedExpression reads the combined statement of a multi-license layout, or of a license
+// with an exception, at path. Returns false when the file isn't one.
+func combinedExpression(path string) (Expression, bool) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Expression{}, false
+	}
+
+	expression, err := ParseLicenseIdentifier(string(content))
+	if err != nil || !expression.Normalize().requiresCombinedStatement() {
+		return Expression{}, false
+	}
+
+	return expression, true
+}
+
+// siblingLicenseFiles returns the LICENSE-<NAME> files next to path, if path is one of them.
+func siblingLicenseFiles(path string) []string {
+	dir, name := filepath.Split(path)
+	if !strings.HasPrefix(name, "LICENSE-") {
+		return nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, "LICENSE-*"))
+	if err != nil {
+		return nil
+	}
+
+	return matches
+}
+
 // FileRepository provides filesystem-based operations for loading and writing licenses.
 type FileRepository struct{}
 
 // Load reads a license file from the specified path and populates the License.
+// If the path is one half of the COPYING and COPYING.LESSER pair, both files are read.
+// If the path holds the combined statement of a multi-license project, the LICENSE-<NAME> file
+// of each license it names is read. If the path is one of several LICENSE-<NAME> files without
+// a combined statement, all of them are read as a choice between their licenses.
 // If the license type requires a NOTICE file, it will also read from a "NOTICE" file in the current directory.
 func (f FileRepository) Load(path string, license *License) error {
-	ll := func() (io.Reader, func() error, error) {
-		return loadFile(path)
-	}
-
 	nl := func() (io.Reader, func() error, error) {
 		return loadFile("NOTICE")
 	}
 
+	if expression, ok := combinedExpression(path); ok {
+		dir := filepath.Dir(path)
+
+		licenseLoaders := make(map[LicenseType]loader)
+		for licenseType, licensePath := range combinedLicensePaths(expression.LicenseTypes()) {
+			licensePath := filepath.Join(dir, licensePath)
+			licenseLoaders[licenseType] = func() (io.Reader, func() error, error) {
+				return loadFile(licensePath)
+			}
+		}
+
+		return LoadCombined(license, expression, licenseLoaders, nl)
+	}
+
+	if siblings := siblingLicenseFiles(path); len(siblings) > 1 {
+		parts := make([]License, 0, len(siblings))
+		expression := Expression{Operator: OR_EXPRESSION}
+
+		for _, sibling := range siblings {
+			ll := func() (io.Reader, func() error, error) {
+				return loadFile(sibling)
+			}
+
+			var part License
+			if err := Load(&part, ll, nl); err != nil {
+				return err
+			}
+
+			parts = append(parts, part)
+		}
+
+		// The text of a license another one incorporates, like the GPL next to the GNU Lesser license, isn't a choice of its own
+		incorporated := make(map[LicenseType]bool)
+		for _, part := range parts {
+			if licenseType, ok := part.licenseType.incorporatedLicense(); ok {
+				incorporated[licenseType] = true
+			}
+		}
+
+		parts = slices.DeleteFunc(parts, func(part License) bool {
+			return incorporated[part.licenseType]
+		})
+
+		for _, part := range parts {
+			expression.Operands = append(expression.Operands, Expression{Operator: SIMPLE_EXPRESSION, LicenseType: part.licenseType})
+		}
+
+		if len(expression.Operands) == 1 {
+			expression = expression.Operands[0]
+		}
+
+		return combineParts(license, expression, parts)
+	}
+
+	ll := func() (io.Reader, func() error, error) {
+		return loadFiles(licenseFilePaths(path)...)
+	}
+
 	return Load(license, ll, nl)
 }
 
@@ -138,7 +295,7 @@ func (f FileRepository) Write(license *License) error {
 	}
 
 	write := func(writeable *Writeable) error {
-		file, err := os.OpenFile(writeable.Path, os.O_CREATE|os.O_WRONLY, 0644)
+		file, err := os.OpenFile(writeable.Path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 		if err != nil {
 			return err
 		}
@@ -160,10 +317,13 @@ func (f FileRepository) Write(license *License) error {
 // It checks for standard license filenames in order of convention preference.
 func DiscoverLicenseFile() (string, error) {
 	// Files without extensions first (standard convention)
+	// COPYING.LESSER comes before COPYING so the GNU Lesser layout,
+	// which ships both, is found by its LGPL half
 	primaryCandidates := []string{
 		"LICENSE",
 		"UNLICENSE",
 		"COPYING.LESSER",
+		"COPYING",
 	}
 
 	// Check primary candidates first
@@ -185,5 +345,10 @@ func DiscoverLicenseFile() (string, error) {
 		}
 	}
 
+	// Multi-license projects without a combined statement, e.g. LICENSE-MIT and LICENSE-APACHE
+	if matches, err := filepath.Glob("LICENSE-*"); err == nil && len(matches) > 0 {
+		return matches[0], nil
+	}
+
 	return "", errors.New("no license file found in current directory")
 }
@@ -2,6 +2,8 @@ package ligen
 
 import (
 	"io"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -63,13 +65,195 @@ func TestFileLoad(t *testing.T) {
 		{
 			name: "Passing-GNU-Lesser-3.0",
 			input: input{
-				licenseType: GNU_LESSER_3_0,
+				licenseType: GNU_LESSER_3_0_OR_LATER,
 				startYear:   2024,
 				endYear:     2025,
 				holder:      "Peanut Butter",
 				projectName: "Ligen",
 			},
 		},
+		{
+			name: "Passing-BSD-0-Clause",
+			input: input{
+				licenseType: BSD_0_CLAUSE,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "", // unused for BSD
+			},
+		},
+		{
+			name: "Passing-BSD-2-Clause",
+			input: input{
+				licenseType: BSD_2_CLAUSE,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "", // unused for BSD
+			},
+		},
+		{
+			name: "Passing-BSD-3-Clause",
+			input: input{
+				licenseType: BSD_3_CLAUSE,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "", // unused for BSD
+			},
+		},
+		{
+			name: "Passing-BSD-4-Clause",
+			input: input{
+				licenseType: BSD_4_CLAUSE,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "", // unused for BSD
+			},
+		},
+		{
+			name: "Passing-GNU-GPL-2.0-Only",
+			input: input{
+				licenseType: GNU_GENERAL_2_0_ONLY,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "Ligen",
+			},
+		},
+		{
+			name: "Passing-GNU-GPL-2.0-Or-Later",
+			input: input{
+				licenseType: GNU_GENERAL_2_0_OR_LATER,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "Ligen",
+			},
+		},
+		{
+			name: "Passing-GNU-GPL-3.0-Only",
+			input: input{
+				licenseType: GNU_GENERAL_3_0_ONLY,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "Ligen",
+			},
+		},
+		{
+			name: "Passing-GNU-GPL-3.0-Or-Later",
+			input: input{
+				licenseType: GNU_GENERAL_3_0_OR_LATER,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "Ligen",
+			},
+		},
+		{
+			name: "Passing-GNU-AGPL-3.0-Only",
+			input: input{
+				licenseType: GNU_AFFERO_3_0_ONLY,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "Ligen",
+			},
+		},
+		{
+			name: "Passing-GNU-AGPL-3.0-Or-Later",
+			input: input{
+				licenseType: GNU_AFFERO_3_0_OR_LATER,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "Ligen",
+			},
+		},
+		{
+			name: "Passing-ISC",
+			input: input{
+				licenseType: ISC,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "",
+			},
+		},
+		{
+			name: "Passing-Zlib",
+			input: input{
+				licenseType: ZLIB,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "",
+			},
+		},
+		{
+			name: "Passing-PostgreSQL",
+			input: input{
+				licenseType: POSTGRESQL,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "",
+			},
+		},
+		{
+			name: "Passing-Unicode-3.0",
+			input: input{
+				licenseType: UNICODE_3_0,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "",
+			},
+		},
+		{
+			name: "Passing-CC-BY-4.0",
+			input: input{
+				licenseType: CC_BY_4_0,
+				startYear:   2024,
+				endYear:     2025,
+				holder:      "Peanut Butter",
+				projectName: "",
+			},
+		},
+		{
+			name: "Passing-CC-BY-SA-4.0",
+			input: input{
+				licenseType: CC_BY_SA_4_0,
+				startYear:   2024,
+				endYear:     2025,
+				holder:      "Peanut Butter",
+				projectName: "",
+			},
+		},
+		{
+			name: "Passing-Eclipse-2.0",
+			input: input{
+				licenseType: ECLIPSE_2_0,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "Ligen",
+			},
+		},
+		{
+			name: "Passing-Elastic-2.0",
+			input: input{
+				licenseType: ELASTIC_2_0,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "Ligen",
+			},
+		},
+		{
+			name: "Passing-PolyForm-Noncommercial-1.0.0",
+			input: input{
+				licenseType: POLYFORM_NONCOMMERCIAL_1_0_0,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "",
+			},
+		},
+		{
+			name: "Passing-Proprietary",
+			input: input{
+				licenseType: PROPRIETARY,
+				startYear:   2024,
+				holder:      "Peanut Butter",
+				projectName: "",
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -77,24 +261,32 @@ func TestFileLoad(t *testing.T) {
 			// GIVEN
 			docs := builder(t, tc.input.licenseType, tc.input.startYear, tc.input.endYear, tc.input.holder, tc.input.projectName)
 
+			// Everything but the NOTICE is license text, read back to back
+			// the same way FileRepository reads the COPYING pair
+			var licenseContent, noticeContent string
+			for _, doc := range docs {
+				if doc.Path == "NOTICE" {
+					noticeContent = doc.Content
+					continue
+				}
+				licenseContent += doc.Content
+			}
+
 			licenseLoader := func() (io.Reader, func() error, error) {
-				return strings.NewReader(docs[0].Content), func() error { return nil }, nil
+				return strings.NewReader(licenseContent), func() error { return nil }, nil
 			}
 
 			noticeLoader := func() (io.Reader, func() error, error) {
-				if len(docs) > 1 {
-					return strings.NewReader(docs[1].Content), func() error { return nil }, nil
-				}
-				return nil, func() error { return nil }, nil
+				return strings.NewReader(noticeContent), func() error { return nil }, nil
 			}
 
 			expected := License{
 				projectName: tc.input.projectName,
-				copyright: Copyright{
+				copyrights: Copyrights{{
 					Holder:    tc.input.holder,
 					EndYear:   tc.input.endYear,
 					StartYear: tc.input.startYear,
-				},
+				}},
 				licenseType: tc.input.licenseType,
 			}
 
@@ -114,3 +306,200 @@ func TestFileLoad(t *testing.T) {
 		})
 	}
 }
+
+func TestLicenseFilePaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []string
+		path     string
+		expected []string
+	}{
+		{
+			name:     "Pass-License",
+			existing: []string{"LICENSE", "COPYING"},
+			path:     "LICENSE",
+			expected: []string{"LICENSE"},
+		},
+		{
+			name:     "Pass-Copying-Alone",
+			existing: []string{"COPYING"},
+			path:     "COPYING",
+			expected: []string{"COPYING"},
+		},
+		{
+			name:     "Pass-Copying-WithLesser",
+			existing: []string{"COPYING", "COPYING.LESSER"},
+			path:     "COPYING",
+			expected: []string{"COPYING", "COPYING.LESSER"},
+		},
+		{
+			name:     "Pass-Lesser-WithCopying",
+			existing: []string{"COPYING", "COPYING.LESSER"},
+			path:     "COPYING.LESSER",
+			expected: []string{"COPYING.LESSER", "COPYING"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// GIVEN
+			dir := t.TempDir()
+			for _, name := range tc.existing {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expected := make([]string, len(tc.expected))
+			for idx, name := range tc.expected {
+				expected[idx] = filepath.Join(dir, name)
+			}
+
+			// WHEN
+			paths := licenseFilePaths(filepath.Join(dir, tc.path))
+
+			// THEN
+			if !reflect.DeepEqual(expected, paths) {
+				t.Errorf("Expected %v, got %v", expected, paths)
+			}
+		})
+	}
+}
+
+func TestLoadGNULesserPair(t *testing.T) {
+	// GIVEN
+	dir := t.TempDir()
+	docs := builder(t, GNU_LESSER_3_0_OR_LATER, 2024, 0, "Peanut Butter", "Ligen")
+	for _, doc := range docs {
+		if err := os.WriteFile(filepath.Join(dir, doc.Path), []byte(doc.Content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	licenseLoader := func() (io.Reader, func() error, error) {
+		return loadFiles(licenseFilePaths(filepath.Join(dir, "COPYING"))...)
+	}
+
+	noticeLoader := func() (io.Reader, func() error, error) {
+		return loadFile(filepath.Join(dir, "NOTICE"))
+	}
+
+	// WHEN
+	var license License
+	err := Load(&license, licenseLoader, noticeLoader)
+
+	// THEN
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if license.licenseType != GNU_LESSER_3_0_OR_LATER {
+		t.Errorf("Expected %s, got %s", GNU_LESSER_3_0_OR_LATER.String(), license.licenseType.String())
+	}
+}
+
+func TestFileRepositoryLoadCombined(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		// files removed before loading, to mimic layouts written by hand
+		remove   []string
+		loadPath string
+		expected string
+	}{
+		{
+			name:       "Pass-CombinedStatement",
+			expression: "MIT OR Apache-2.0",
+			loadPath:   "LICENSE",
+			expected:   "MIT OR Apache-2.0",
+		},
+		{
+			name:       "Pass-CombinedStatement-And",
+			expression: "BSD-2-Clause AND BSD-3-Clause",
+			loadPath:   "LICENSE",
+			expected:   "BSD-2-Clause AND BSD-3-Clause",
+		},
+		{
+			name:       "Pass-CombinedStatement-Exception",
+			expression: "GPL-2.0-or-later WITH Classpath-exception-2.0",
+			loadPath:   "LICENSE",
+			expected:   "GPL-2.0-or-later WITH Classpath-exception-2.0",
+		},
+		{
+			name:       "Pass-NoCombinedStatement",
+			expression: "MIT OR Apache-2.0",
+			remove:     []string{"LICENSE"},
+			loadPath:   "LICENSE-APACHE",
+			expected:   "Apache-2.0 OR MIT",
+		},
+		{
+			name:       "Pass-NoCombinedStatement-Incorporated",
+			expression: "LGPL-3.0-or-later OR MIT",
+			remove:     []string{"LICENSE"},
+			loadPath:   "LICENSE-GPL",
+			expected:   "LGPL-3.0-or-later OR MIT",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// GIVEN
+			dir := t.TempDir()
+			wd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(wd)
+
+			expression, err := ParseExpression(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			license, err := NewFromExpression("Ligen", "Peanut Butter", 2024, 0, expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			repo := FileRepository{}
+			if err := repo.Write(license); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, name := range tc.remove {
+				if err := os.Remove(name); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			discovered, err := DiscoverLicenseFile()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if discovered != tc.loadPath {
+				t.Errorf("Expected to discover %s, got %s", tc.loadPath, discovered)
+			}
+
+			// WHEN
+			var loaded License
+			err = repo.Load(discovered, &loaded)
+
+			// THEN
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if loaded.licenseExpression().String() != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, loaded.licenseExpression().String())
+			}
+
+			if !reflect.DeepEqual(loaded.copyrights, license.copyrights) {
+				t.Errorf("Expected copyrights %v, got %v", license.copyrights, loaded.copyrights)
+			}
+		})
+	}
+}
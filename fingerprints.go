@@ -0,0 +1,104 @@
+package ligen
+
+import (
+	"slices"
+	"sync"
+)
+
+// fingerprint is a license text normalized with NormalizeLicenseText along with its bigrams,
+// so the text isn't normalized and split into bigrams again every time content is compared against it
+type fingerprint struct {
+	licenseType LicenseType
+	normalized  string
+	bigrams     biggrams
+}
+
+func newFingerprint(licenseType LicenseType) (fingerprint, error) {
+	tmp, err := licenseType.Template()
+	if err != nil {
+		return fingerprint{}, err
+	}
+
+	normalized := NormalizeLicenseText(tmp)
+
+	return fingerprint{licenseType: licenseType, normalized: normalized, bigrams: newBigrams(normalized)}, nil
+}
+
+// licenseFingerprints fingerprints every known license text the first time it's called and returns the
+// same fingerprints from then on. "Or later" variants are left out since they share their text with the "only" variant.
+// The fingerprints are only ever read once computed, so they can be shared between goroutines.
+var licenseFingerprints = sync.OnceValues(func() ([]fingerprint, error) {
+	knownLicenseTypes := AllLicensesTypes()
+	fingerprints := make([]fingerprint, 0, len(knownLicenseTypes))
+
+	for _, licenseType := range knownLicenseTypes {
+		if isOrLaterVariant(licenseType) {
+			continue
+		}
+
+		fp, err := newFingerprint(licenseType)
+		if err != nil {
+			return nil, err
+		}
+
+		fingerprints = append(fingerprints, fp)
+	}
+
+	return fingerprints, nil
+})
+
+// Matcher detects licenses by comparing content against fingerprints of the known license texts.
+// The fingerprints are computed once and shared by every Matcher, which makes a Matcher cheap to create
+// and fast to reuse when scanning many files, e.g. every vendored LICENSE file of a project.
+// A Matcher is never modified once created, so it's safe for concurrent use.
+type Matcher struct {
+	threshold    float64
+	fingerprints []fingerprint
+}
+
+// NewMatcher creates a Matcher with the minimum similarity score (0.0-1.0) required for a match.
+// Returns an error if the license texts can't be fingerprinted.
+func NewMatcher(threshold float64) (Matcher, error) {
+	fingerprints, err := licenseFingerprints()
+	if err != nil {
+		return Matcher{}, err
+	}
+
+	return Matcher{threshold: threshold, fingerprints: fingerprints}, nil
+}
+
+// Threshold returns the minimum similarity score required for a match.
+func (m Matcher) Threshold() float64 {
+	return m.threshold
+}
+
+// fingerprint returns the fingerprint of the license type, "or later" variants share the fingerprint of the "only" variant
+func (m Matcher) fingerprint(licenseType LicenseType) (fingerprint, bool) {
+	licenseType = onlyVariant(licenseType)
+
+	idx := slices.IndexFunc(m.fingerprints, func(fp fingerprint) bool {
+		return fp.licenseType == licenseType
+	})
+	if idx == -1 {
+		return fingerprint{}, false
+	}
+
+	return m.fingerprints[idx], true
+}
+
+// fingerprint returns the cached fingerprint of the license text.
+// Returns the error of Template for license types without a text.
+func (lt LicenseType) fingerprint() (fingerprint, error) {
+	matcher, err := NewMatcher(0)
+	if err != nil {
+		return fingerprint{}, err
+	}
+
+	fp, ok := matcher.fingerprint(lt)
+	if !ok {
+		_, err := lt.Template()
+		return fingerprint{}, err
+	}
+
+	return fp, nil
+}
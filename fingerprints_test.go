@@ -0,0 +1,305 @@
+package ligen
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// licenseCorpus renders every known license, along with a bundle of all of them
+func licenseCorpus(tb testing.TB) (map[LicenseType]string, string) {
+	tb.Helper()
+
+	copyright, err := testPolicy.NewCopyright("Max Moon", 2025, 0)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	// Parameters every license can be rendered with
+	params := Parameters{ChangeDate: time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC), ChangeLicense: "Apache License, Version 2.0"}
+
+	corpus := make(map[LicenseType]string)
+	var bundle strings.Builder
+
+	for _, licenseType := range AllLicensesTypes() {
+		generatorFunc, err := licenseType.GeneratorFunc()
+		if err != nil {
+			tb.Fatal(err)
+		}
+
+		projectName := "Ligen"
+		copyrights := Copyrights{copyright}
+
+		var buf bytes.Buffer
+		writeables, err := generatorFunc(&projectName, &copyrights, &params, &buf)
+		if err != nil {
+			tb.Fatal(err)
+		}
+
+		// Every file but the NOTICE, the way FileRepository reads the license
+		var files []string
+		for _, writeable := range writeables {
+			if writeable.Path != "NOTICE" {
+				files = append(files, writeable.Content)
+			}
+		}
+
+		rendered := strings.Join(files, "")
+
+		corpus[licenseType] = rendered
+
+		if !isOrLaterVariant(licenseType) {
+			bundle.WriteString(rendered)
+			bundle.WriteString("\n\n----------------------------------------\n\n")
+		}
+	}
+
+	return corpus, bundle.String()
+}
+
+func TestMatcher(t *testing.T) {
+	corpus, bundle := licenseCorpus(t)
+
+	matcher, err := NewMatcher(0.90)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	if matcher.Threshold() != 0.90 {
+		t.Errorf("expected threshold of 0.90, got %f", matcher.Threshold())
+	}
+
+	for _, licenseType := range AllLicensesTypes() {
+		t.Run(licenseType.String(), func(t *testing.T) {
+			// The license text alone doesn't say whether "or later" applies
+			expected := onlyVariant(licenseType)
+
+			detection, err := matcher.Detect(corpus[licenseType])
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+
+			best, err := detection.Match()
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+
+			if best.LicenseType != expected {
+				t.Errorf("expected %s, got %s", expected, best.LicenseType)
+			}
+
+			if best.Score < matcher.Threshold() {
+				t.Errorf("expected a score of at least %f, got %f", matcher.Threshold(), best.Score)
+			}
+
+			match, err := matcher.Match(corpus[licenseType])
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+
+			if match != expected {
+				t.Errorf("expected %s, got %s", expected, match)
+			}
+		})
+	}
+
+	located, err := matcher.LocateLicenses(bundle)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	found := make(map[LicenseType]bool)
+	for _, embedded := range located {
+		found[embedded.LicenseType] = true
+	}
+
+	for _, licenseType := range AllLicensesTypes() {
+		if !isOrLaterVariant(licenseType) && !found[licenseType] {
+			t.Errorf("expected %s to be located in the bundle", licenseType)
+		}
+	}
+}
+
+func TestMatcherConcurrentUse(t *testing.T) {
+	corpus, _ := licenseCorpus(t)
+
+	matcher, err := NewMatcher(0.90)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, len(corpus)*4)
+
+	for range 4 {
+		for licenseType, content := range corpus {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				match, err := matcher.Match(content)
+				if err != nil {
+					errs <- licenseType.String() + ": " + err.Error()
+					return
+				}
+
+				if expected := onlyVariant(licenseType); match != expected {
+					errs <- "expected " + expected.String() + ", got " + match.String()
+				}
+			}()
+		}
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestCompareUsesFingerprint(t *testing.T) {
+	corpus, _ := licenseCorpus(t)
+
+	for _, licenseType := range []LicenseType{MIT, APACHE_2_0, GNU_GENERAL_3_0_OR_LATER} {
+		t.Run(licenseType.String(), func(t *testing.T) {
+			var compared string
+			score, err := licenseType.Compare(corpus[licenseType], func(left, right string) float64 {
+				compared = right
+				return SorensonDiceCoefficient(left, right)
+			})
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+
+			tmp, err := licenseType.Template()
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+
+			if compared != NormalizeLicenseText(tmp) {
+				t.Errorf("expected the normalized template to be compared against")
+			}
+
+			if score < 0.90 {
+				t.Errorf("expected a score of at least 0.90, got %f", score)
+			}
+		})
+	}
+
+	if _, err := LicenseType(-1).Compare("", SorensonDiceCoefficient); err == nil {
+		t.Errorf("expected an error for an unknown license type")
+	}
+}
+
+func TestMatcherFingerprintError(t *testing.T) {
+	broken := errors.New("broken template")
+
+	original := licenseFingerprints
+	licenseFingerprints = func() ([]fingerprint, error) { return nil, broken }
+	t.Cleanup(func() { licenseFingerprints = original })
+
+	if _, err := NewMatcher(0.90); !errors.Is(err, broken) {
+		t.Errorf("expected %s from NewMatcher, got %v", broken, err)
+	}
+
+	if _, err := Detect("MIT License", 0.90); !errors.Is(err, broken) {
+		t.Errorf("expected %s from Detect, got %v", broken, err)
+	}
+
+	if _, err := Match("MIT License", 0.90); !errors.Is(err, broken) {
+		t.Errorf("expected %s from Match, got %v", broken, err)
+	}
+
+	if _, err := LocateLicenses("MIT License", 0.90); !errors.Is(err, broken) {
+		t.Errorf("expected %s from LocateLicenses, got %v", broken, err)
+	}
+}
+
+func BenchmarkMatch(b *testing.B) {
+	corpus, _ := licenseCorpus(b)
+
+	for _, licenseType := range AllLicensesTypes() {
+		b.Run(licenseType.String(), func(b *testing.B) {
+			for range b.N {
+				if _, err := Match(corpus[licenseType], 0.90); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDetectCorpus(b *testing.B) {
+	corpus, _ := licenseCorpus(b)
+
+	for range b.N {
+		for _, content := range corpus {
+			if _, err := Detect(content, 0.90); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkLocateLicensesBundle(b *testing.B) {
+	_, bundle := licenseCorpus(b)
+
+	for range b.N {
+		if _, err := LocateLicenses(bundle, 0.90); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMatcherMatch(b *testing.B) {
+	corpus, _ := licenseCorpus(b)
+
+	matcher, err := NewMatcher(0.90)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, licenseType := range AllLicensesTypes() {
+		b.Run(licenseType.String(), func(b *testing.B) {
+			for range b.N {
+				if _, err := matcher.Match(corpus[licenseType]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMatcherCorpusParallel(b *testing.B) {
+	corpus, _ := licenseCorpus(b)
+
+	matcher, err := NewMatcher(0.90)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for _, content := range corpus {
+				if _, err := matcher.Detect(content); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkSorensonDiceCoefficient(b *testing.B) {
+	corpus, _ := licenseCorpus(b)
+
+	left, right := corpus[APACHE_2_0], corpus[MIT]
+
+	for range b.N {
+		SorensonDiceCoefficient(left, right)
+	}
+}
@@ -0,0 +1,351 @@
+package ligen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	GitCommandError     = errors.New("git command failed")
+	NoCommitsFoundError = errors.New("no commits found")
+	InvalidMailmapError = errors.New("invalid mailmap")
+)
+
+// mailmapEntry maps the name and email a commit was made under to the proper ones.
+// Empty proper fields are left as they are, an empty commit name matches any name.
+type mailmapEntry struct {
+	properName  string
+	properEmail string
+	commitName  string
+	commitEmail string
+}
+
+// Mailmap maps the names and emails commits were made under to the ones they should be credited as,
+// using the format of git's .mailmap file.
+type Mailmap struct {
+	entries []mailmapEntry
+}
+
+var mailmapLinePattern = regexp.MustCompile(`^([^<]*?)\s*<([^>]*)>(?:\s*([^<]*?)\s*<([^>]*)>)?$`)
+
+// ParseMailmap parses an alias table in the format of git's .mailmap, one of these forms per line:
+//
+//	Proper Name <commit@email>
+//	<proper@email> <commit@email>
+//	Proper Name <proper@email> <commit@email>
+//	Proper Name <proper@email> Commit Name <commit@email>
+func ParseMailmap(document string) (Mailmap, error) {
+	var mailmap Mailmap
+
+	for idx, line := range strings.Split(strings.ReplaceAll(document, "\r\n", "\n"), "\n") {
+		if comment := strings.Index(line, "#"); comment >= 0 {
+			line = line[:comment]
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		matches := mailmapLinePattern.FindStringSubmatch(line)
+		if matches == nil {
+			return Mailmap{}, fmt.Errorf("%w: expected a name and email on line %d", InvalidMailmapError, idx+1)
+		}
+
+		entry := mailmapEntry{properName: matches[1], commitEmail: matches[2]}
+		if matches[4] != "" {
+			entry = mailmapEntry{properName: matches[1], properEmail: matches[2], commitName: matches[3], commitEmail: matches[4]}
+		}
+
+		mailmap.entries = append(mailmap.entries, entry)
+	}
+
+	return mailmap, nil
+}
+
+// Resolve returns the proper name and email for a commit author. Entries naming the commit's
+// author take priority over the ones only matching its email, like git does.
+func (m Mailmap) Resolve(name, email string) (string, string) {
+	var match *mailmapEntry
+
+	for idx := range m.entries {
+		entry := &m.entries[idx]
+		if !strings.EqualFold(entry.commitEmail, email) {
+			continue
+		}
+
+		if entry.commitName == "" && match == nil {
+			match = entry
+		}
+
+		if entry.commitName == name {
+			match = entry
+			break
+		}
+	}
+
+	if match == nil {
+		return name, email
+	}
+
+	if match.properName != "" {
+		name = match.properName
+	}
+
+	if match.properEmail != "" {
+		email = match.properEmail
+	}
+
+	return name, email
+}
+
+// HistoryGranularity is how much of the history copyrights are derived from.
+type HistoryGranularity int
+
+const (
+	// PER_REPOSITORY derives copyrights from every commit in the repository
+	PER_REPOSITORY HistoryGranularity = iota + 1
+	// PER_FILE derives copyrights from the commits touching the file,
+	// falling back to the whole repository for files that have none yet
+	PER_FILE
+)
+
+// GitHistoryOptions configures how GitHistory derives copyrights.
+type GitHistoryOptions struct {
+	// Granularity defaults to PER_REPOSITORY
+	Granularity HistoryGranularity
+	// Aliases credits authors under their proper name, on top of the repository's own .mailmap
+	Aliases Mailmap
+	// IgnoreBots leaves out commits made by bots, like dependabot[bot]
+	IgnoreBots bool
+	// Policy validates the derived copyrights, its clock tells the year of files that haven't been committed yet.
+	// DefaultValidationPolicy when nil, Service sets its own policy when none is given.
+	Policy *ValidationPolicy
+}
+
+// GitHistory derives copyright years and holders from the commits of a local git repository.
+// The start year is the earliest year of the commits, the end year the latest one, so history
+// that was rebased or cherry-picked out of order still gives a valid range.
+// It runs the git executable and never reaches out to the network.
+type GitHistory struct {
+	root    string
+	options GitHistoryOptions
+}
+
+// NewGitHistory creates a GitHistory reading the repository at root.
+func NewGitHistory(root string, options GitHistoryOptions) GitHistory {
+	if options.Granularity == 0 {
+		options.Granularity = PER_REPOSITORY
+	}
+
+	return GitHistory{root: root, options: options}
+}
+
+// validationPolicy returns the policy the copyrights are validated with
+func (g GitHistory) validationPolicy() ValidationPolicy {
+	if g.options.Policy == nil {
+		return DefaultValidationPolicy()
+	}
+
+	return *g.options.Policy
+}
+
+// yearSpan returns the earliest and latest year of the commits, author dates aren't always in order
+func yearSpan(commits []commit) (int, int) {
+	first, last := commits[0].year, commits[0].year
+	for _, current := range commits[1:] {
+		first = min(first, current.year)
+		last = max(last, current.year)
+	}
+
+	return first, last
+}
+
+// commit is the part of a commit copyrights are derived from
+type commit struct {
+	name  string
+	email string
+	year  int
+}
+
+var botAuthorPattern = regexp.MustCompile(`(?i)\[bot\]|^(?:dependabot|renovate|github-actions|greenkeeper|snyk-bot)\b`)
+
+func isBot(c commit) bool {
+	return botAuthorPattern.MatchString(c.name) || botAuthorPattern.MatchString(c.email)
+}
+
+// log returns the commits touching path, or every commit when path is empty, newest first
+func (g GitHistory) log(path string) ([]commit, error) {
+	args := []string{"-C", g.root, "log", "--format=%aN%x00%aE%x00%ad", "--date=format:%Y"}
+	if path != "" {
+		absolute, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--follow", "--", absolute)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", GitCommandError, strings.TrimSpace(stderr.String()))
+	}
+
+	var commits []commit
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 3 {
+			continue
+		}
+
+		year, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid commit year %s", GitCommandError, fields[2])
+		}
+
+		current := commit{year: year}
+		current.name, current.email = g.options.Aliases.Resolve(fields[0], fields[1])
+
+		if g.options.IgnoreBots && isBot(current) {
+			continue
+		}
+
+		commits = append(commits, current)
+	}
+
+	return commits, nil
+}
+
+// commits returns the commits copyrights for path are derived from, following the granularity
+func (g GitHistory) commits(path string) ([]commit, error) {
+	if g.options.Granularity == PER_FILE {
+		commits, err := g.log(path)
+		if err != nil || len(commits) > 0 {
+			return commits, err
+		}
+	}
+
+	commits, err := g.log("")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(commits) == 0 {
+		return nil, NoCommitsFoundError
+	}
+
+	return commits, nil
+}
+
+// authorHistory summarizes the commits of one author
+type authorHistory struct {
+	copyright Copyright
+	commits   int
+}
+
+// summarize groups commits by author, ordered by the year of their first commit, then name
+func summarize(commits []commit) []authorHistory {
+	byAuthor := make(map[string]*authorHistory)
+	var authors []*authorHistory
+
+	for _, current := range commits {
+		author, ok := byAuthor[current.name]
+		if !ok {
+			author = &authorHistory{copyright: Copyright{Holder: current.name, StartYear: current.year}}
+			byAuthor[current.name] = author
+			authors = append(authors, author)
+		}
+
+		author.commits++
+		author.copyright.StartYear = min(author.copyright.StartYear, current.year)
+		author.copyright.EndYear = max(author.copyright.EndYear, current.year)
+	}
+
+	summary := make([]authorHistory, 0, len(authors))
+	for _, author := range authors {
+		if author.copyright.EndYear == author.copyright.StartYear {
+			author.copyright.EndYear = 0
+		}
+		summary = append(summary, *author)
+	}
+
+	sort.SliceStable(summary, func(i, j int) bool {
+		if summary[i].copyright.StartYear != summary[j].copyright.StartYear {
+			return summary[i].copyright.StartYear < summary[j].copyright.StartYear
+		}
+		return summary[i].copyright.Holder < summary[j].copyright.Holder
+	})
+
+	return summary
+}
+
+// Holders returns a copyright for each author of path, or of the repository when path is empty,
+// spanning the years of their commits. Authors are ordered by the year of their first commit.
+func (g GitHistory) Holders(path string) ([]Copyright, error) {
+	commits, err := g.commits(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var holders []Copyright
+	for _, author := range summarize(commits) {
+		holders = append(holders, author.copyright)
+	}
+
+	return holders, nil
+}
+
+// Copyright returns the copyright of path, or of the repository when path is empty, spanning the years
+// of the earliest and latest commit. The holder is the author with the most commits.
+func (g GitHistory) Copyright(path string) (Copyright, error) {
+	commits, err := g.commits(path)
+	if err != nil {
+		return Copyright{}, err
+	}
+
+	var holder authorHistory
+	for _, author := range summarize(commits) {
+		if author.commits > holder.commits {
+			holder = author
+		}
+	}
+
+	copyright := Copyright{Holder: holder.copyright.Holder}
+	copyright.StartYear, copyright.EndYear = yearSpan(commits)
+	if copyright.EndYear == copyright.StartYear {
+		copyright.EndYear = 0
+	}
+
+	if err := g.validationPolicy().validateCopyright(copyright); err != nil {
+		return Copyright{}, err
+	}
+
+	return copyright, nil
+}
+
+// ModifiedYear returns the latest year of the commits touching path, or the current year of the policy's
+// clock for files that haven't been committed yet. It can be used as the ModifiedYearFunc of BumpYearOptions.
+func (g GitHistory) ModifiedYear(path string) (int, error) {
+	commits, err := g.log(path)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(commits) == 0 {
+		return g.validationPolicy().currentYear(), nil
+	}
+
+	_, last := yearSpan(commits)
+
+	return last, nil
+}
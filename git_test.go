@@ -0,0 +1,294 @@
+package ligen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// gitRepo creates a repository in a temporary directory, skipping the test when git isn't installed
+func gitRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	runGit(t, root, nil, "init", "--quiet")
+
+	return root
+}
+
+func runGit(t *testing.T, root string, env []string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", append([]string{"-C", root, "-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_CONFIG_NOSYSTEM=1", "GIT_CONFIG_GLOBAL="+os.DevNull)
+	cmd.Env = append(cmd.Env, env...)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %s", args, output)
+	}
+}
+
+// gitCommit writes a file and commits it as the given author, dated in the given year
+func gitCommit(t *testing.T, root, name, email string, year int, path, content string) {
+	t.Helper()
+
+	full := filepath.Join(root, path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	date := time.Date(year, 6, 1, 12, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	env := []string{
+		"GIT_AUTHOR_NAME=" + name,
+		"GIT_AUTHOR_EMAIL=" + email,
+		"GIT_AUTHOR_DATE=" + date,
+		"GIT_COMMITTER_NAME=" + name,
+		"GIT_COMMITTER_EMAIL=" + email,
+		"GIT_COMMITTER_DATE=" + date,
+	}
+
+	runGit(t, root, env, "add", path)
+	runGit(t, root, env, "commit", "--quiet", "-m", "Update "+path)
+}
+
+func TestParseMailmap(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		author       [2]string
+		expected     [2]string
+		errorMessage string
+	}{
+		{
+			name:     "Pass-ProperName",
+			input:    "Peanut Butter <pb@example.com>\n",
+			author:   [2]string{"pb", "PB@example.com"},
+			expected: [2]string{"Peanut Butter", "PB@example.com"},
+		},
+		{
+			name:     "Pass-ProperEmail",
+			input:    "<pb@example.com> <pb@old.example.com> # moved\n",
+			author:   [2]string{"Peanut Butter", "pb@old.example.com"},
+			expected: [2]string{"Peanut Butter", "pb@example.com"},
+		},
+		{
+			name: "Pass-CommitNameTakesPriority",
+			input: `Someone <shared@example.com>
+Peanut Butter <pb@example.com> pb <shared@example.com>
+`,
+			author:   [2]string{"pb", "shared@example.com"},
+			expected: [2]string{"Peanut Butter", "pb@example.com"},
+		},
+		{
+			name:     "Pass-NoMatch",
+			input:    "Peanut Butter <pb@example.com>\n",
+			author:   [2]string{"Jelly", "jelly@example.com"},
+			expected: [2]string{"Jelly", "jelly@example.com"},
+		},
+		{
+			name:         "Fail-MissingEmail",
+			input:        "# aliases\nPeanut Butter\n",
+			errorMessage: "invalid mailmap: expected a name and email on line 2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mailmap, err := ParseMailmap(tc.input)
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			name, email := mailmap.Resolve(tc.author[0], tc.author[1])
+			if [2]string{name, email} != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, [2]string{name, email})
+			}
+		})
+	}
+}
+
+func TestGitHistory(t *testing.T) {
+	root := gitRepo(t)
+
+	gitCommit(t, root, "Peanut Butter", "pb@example.com", 2019, "main.go", "package main\n")
+	gitCommit(t, root, "pb", "pb@old.example.com", 2021, "main.go", "package main\n\nfunc main() {}\n")
+	gitCommit(t, root, "Jelly", "jelly@example.com", 2021, "lib/lib.go", "package lib\n")
+	gitCommit(t, root, "dependabot[bot]", "49699333+dependabot[bot]@users.noreply.github.com", 2023, "go.mod", "module example.com/ligen\n")
+
+	aliases, err := ParseMailmap("Peanut Butter <pb@example.com> <pb@old.example.com>\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		options         GitHistoryOptions
+		path            string
+		expected        Copyright
+		expectedHolders []Copyright
+	}{
+		{
+			name:     "Pass-Repository",
+			options:  GitHistoryOptions{Aliases: aliases},
+			path:     "",
+			expected: Copyright{Holder: "Peanut Butter", StartYear: 2019, EndYear: 2023},
+			expectedHolders: []Copyright{
+				{Holder: "Peanut Butter", StartYear: 2019, EndYear: 2021},
+				{Holder: "Jelly", StartYear: 2021},
+				{Holder: "dependabot[bot]", StartYear: 2023},
+			},
+		},
+		{
+			name:     "Pass-Repository-IgnoreBots",
+			options:  GitHistoryOptions{Aliases: aliases, IgnoreBots: true},
+			path:     "",
+			expected: Copyright{Holder: "Peanut Butter", StartYear: 2019, EndYear: 2021},
+			expectedHolders: []Copyright{
+				{Holder: "Peanut Butter", StartYear: 2019, EndYear: 2021},
+				{Holder: "Jelly", StartYear: 2021},
+			},
+		},
+		{
+			name:    "Pass-Repository-IgnoresFile",
+			options: GitHistoryOptions{Granularity: PER_REPOSITORY, IgnoreBots: true},
+			path:    filepath.Join(root, "lib/lib.go"),
+			// Without aliases every author made one commit, the earliest one wins
+			expected: Copyright{Holder: "Peanut Butter", StartYear: 2019, EndYear: 2021},
+			expectedHolders: []Copyright{
+				{Holder: "Peanut Butter", StartYear: 2019},
+				{Holder: "Jelly", StartYear: 2021},
+				{Holder: "pb", StartYear: 2021},
+			},
+		},
+		{
+			name:     "Pass-PerFile",
+			options:  GitHistoryOptions{Granularity: PER_FILE, Aliases: aliases},
+			path:     filepath.Join(root, "main.go"),
+			expected: Copyright{Holder: "Peanut Butter", StartYear: 2019, EndYear: 2021},
+			expectedHolders: []Copyright{
+				{Holder: "Peanut Butter", StartYear: 2019, EndYear: 2021},
+			},
+		},
+		{
+			name:     "Pass-PerFile-Uncommitted",
+			options:  GitHistoryOptions{Granularity: PER_FILE, Aliases: aliases, IgnoreBots: true},
+			path:     filepath.Join(root, "new.go"),
+			expected: Copyright{Holder: "Peanut Butter", StartYear: 2019, EndYear: 2021},
+			expectedHolders: []Copyright{
+				{Holder: "Peanut Butter", StartYear: 2019, EndYear: 2021},
+				{Holder: "Jelly", StartYear: 2021},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			history := NewGitHistory(root, tc.options)
+
+			copyright, err := history.Copyright(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, copyright) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, copyright)
+			}
+
+			holders, err := history.Holders(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedHolders, holders) {
+				t.Errorf("Expected holders %+v, got %+v", tc.expectedHolders, holders)
+			}
+		})
+	}
+}
+
+func TestGitHistoryModifiedYear(t *testing.T) {
+	root := gitRepo(t)
+	gitCommit(t, root, "Peanut Butter", "pb@example.com", 2021, "main.go", "package main\n")
+
+	history := NewGitHistory(root, GitHistoryOptions{Policy: &testPolicy})
+
+	year, err := history.ModifiedYear(filepath.Join(root, "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if year != 2021 {
+		t.Errorf("Expected 2021, got %d", year)
+	}
+
+	// Files that were never committed are being worked on now, according to the policy's clock
+	year, err = history.ModifiedYear(filepath.Join(root, "new.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if year != 2025 {
+		t.Errorf("Expected 2025, got %d", year)
+	}
+}
+
+func TestGitHistoryOutOfOrderDates(t *testing.T) {
+	root := gitRepo(t)
+
+	gitCommit(t, root, "Peanut Butter", "pb@example.com", 2019, "main.go", "package main\n")
+	gitCommit(t, root, "Peanut Butter", "pb@example.com", 2023, "main.go", "package main\n\nfunc main() {}\n")
+	// Cherry-picked from an older branch, its author date predates the commits before it
+	gitCommit(t, root, "Peanut Butter", "pb@example.com", 2018, "main.go", "package main\n\nfunc main() {}\n\nfunc run() {}\n")
+
+	history := NewGitHistory(root, GitHistoryOptions{Policy: &testPolicy})
+
+	copyright, err := history.Copyright("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Copyright{Holder: "Peanut Butter", StartYear: 2018, EndYear: 2023}
+	if !reflect.DeepEqual(expected, copyright) {
+		t.Errorf("Expected %+v, got %+v", expected, copyright)
+	}
+
+	year, err := history.ModifiedYear(filepath.Join(root, "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if year != 2023 {
+		t.Errorf("Expected 2023, got %d", year)
+	}
+}
+
+func TestGitHistoryPolicy(t *testing.T) {
+	root := gitRepo(t)
+	gitCommit(t, root, "Peanut Butter", "pb@example.com", 2019, "main.go", "package main\n")
+
+	policy := testPolicy
+	policy.MaxYearsPast = 2
+
+	_, err := NewGitHistory(root, GitHistoryOptions{Policy: &policy}).Copyright("")
+	checkError(StartYearTooOldError.Error(), err, t)
+}
+
+func TestGitHistoryNoCommits(t *testing.T) {
+	root := gitRepo(t)
+
+	_, err := NewGitHistory(root, GitHistoryOptions{}).Copyright("")
+	if err == nil {
+		t.Error("Expected an error for a repository without commits")
+	}
+}
@@ -170,10 +170,29 @@ permanent authorization for you to choose that version for the
 Library.
 `
 
-// Template for GNU Lesser notice
-const GnuLesserNoticeTemplateBody = `{{.ProjectName}}
-Copyright (C) {{.StartYear}}{{if (gt .EndYear 0) }}-{{.EndYear}}{{end}} {{.Holder}}
+// Template for GNU Lesser "only" notice
+const GnuLesserOnlyNoticeTemplateBody = `{{.ProjectName}}
+{{range .Copyrights}}Copyright (C) {{.Years}} {{.Credit}}
+{{end}}
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Lesser General Public License as published by
+the Free Software Foundation, version 3 of the License.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Lesser General Public License for more details.
 
+You should have received a copy of the GNU Lesser General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+`
+
+var GnuLesserOnlyNoticeTemplate = template.Must(template.New("GnuLesserOnlyNotice").Parse(GnuLesserOnlyNoticeTemplateBody))
+
+// Template for GNU Lesser "or later" notice
+const GnuLesserNoticeTemplateBody = `{{.ProjectName}}
+{{range .Copyrights}}Copyright (C) {{.Years}} {{.Credit}}
+{{end}}
 This program is free software: you can redistribute it and/or modify
 it under the terms of the GNU Lesser General Public License as published by
 the Free Software Foundation, either version 3 of the License, or
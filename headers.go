@@ -0,0 +1,623 @@
+package ligen
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// CommentStyle is the comment syntax of a language. Languages with line comments set Line,
+// the others wrap the header in a block comment from Start to End with Middle on each line.
+type CommentStyle struct {
+	Line   string
+	Start  string
+	Middle string
+	End    string
+}
+
+var (
+	SlashCommentStyle     = CommentStyle{Line: "//"}
+	HashCommentStyle      = CommentStyle{Line: "#"}
+	DashCommentStyle      = CommentStyle{Line: "--"}
+	SemicolonCommentStyle = CommentStyle{Line: ";;"}
+	PercentCommentStyle   = CommentStyle{Line: "%"}
+	CBlockCommentStyle    = CommentStyle{Start: "/*", Middle: " *", End: " */"}
+	MarkupCommentStyle    = CommentStyle{Start: "<!--", End: "-->"}
+)
+
+var commentStylesByExtension = map[string]CommentStyle{
+	".go":     SlashCommentStyle,
+	".ts":     SlashCommentStyle,
+	".tsx":    SlashCommentStyle,
+	".js":     SlashCommentStyle,
+	".jsx":    SlashCommentStyle,
+	".mjs":    SlashCommentStyle,
+	".cjs":    SlashCommentStyle,
+	".java":   SlashCommentStyle,
+	".kt":     SlashCommentStyle,
+	".kts":    SlashCommentStyle,
+	".scala":  SlashCommentStyle,
+	".swift":  SlashCommentStyle,
+	".rs":     SlashCommentStyle,
+	".c":      SlashCommentStyle,
+	".h":      SlashCommentStyle,
+	".cc":     SlashCommentStyle,
+	".cpp":    SlashCommentStyle,
+	".hpp":    SlashCommentStyle,
+	".cs":     SlashCommentStyle,
+	".dart":   SlashCommentStyle,
+	".proto":  SlashCommentStyle,
+	".groovy": SlashCommentStyle,
+	".gradle": SlashCommentStyle,
+	".scss":   SlashCommentStyle,
+	".py":     HashCommentStyle,
+	".sh":     HashCommentStyle,
+	".bash":   HashCommentStyle,
+	".zsh":    HashCommentStyle,
+	".rb":     HashCommentStyle,
+	".pl":     HashCommentStyle,
+	".r":      HashCommentStyle,
+	".yaml":   HashCommentStyle,
+	".yml":    HashCommentStyle,
+	".toml":   HashCommentStyle,
+	".tf":     HashCommentStyle,
+	".hcl":    HashCommentStyle,
+	".cmake":  HashCommentStyle,
+	".nix":    HashCommentStyle,
+	".ex":     HashCommentStyle,
+	".exs":    HashCommentStyle,
+	".jl":     HashCommentStyle,
+	".ps1":    HashCommentStyle,
+	".sql":    DashCommentStyle,
+	".lua":    DashCommentStyle,
+	".hs":     DashCommentStyle,
+	".elm":    DashCommentStyle,
+	".el":     SemicolonCommentStyle,
+	".clj":    SemicolonCommentStyle,
+	".lisp":   SemicolonCommentStyle,
+	".scm":    SemicolonCommentStyle,
+	".tex":    PercentCommentStyle,
+	".erl":    PercentCommentStyle,
+	".css":    CBlockCommentStyle,
+	".html":   MarkupCommentStyle,
+	".htm":    MarkupCommentStyle,
+	".xml":    MarkupCommentStyle,
+	".svg":    MarkupCommentStyle,
+	".vue":    MarkupCommentStyle,
+	".md":     MarkupCommentStyle,
+}
+
+var commentStylesByName = map[string]CommentStyle{
+	"Makefile":       HashCommentStyle,
+	"Dockerfile":     HashCommentStyle,
+	"CMakeLists.txt": HashCommentStyle,
+}
+
+// CommentStyleForPath returns the comment syntax for a file, based on its name or extension.
+// Returns false for files headers aren't written to.
+func CommentStyleForPath(path string) (CommentStyle, bool) {
+	name := filepath.Base(path)
+
+	if style, ok := commentStylesByName[name]; ok {
+		return style, true
+	}
+
+	style, ok := commentStylesByExtension[strings.ToLower(filepath.Ext(name))]
+
+	return style, ok
+}
+
+// Wrap turns each line of text into a comment.
+func (s CommentStyle) Wrap(text string) string {
+	lines := strings.Split(text, "\n")
+
+	wrapped := make([]string, 0, len(lines)+2)
+	if s.Line == "" {
+		wrapped = append(wrapped, s.Start)
+	}
+
+	for _, line := range lines {
+		wrapped = append(wrapped, s.commentLine(line))
+	}
+
+	if s.Line == "" {
+		wrapped = append(wrapped, s.End)
+	}
+
+	return strings.Join(wrapped, "\n")
+}
+
+// commentLine turns a line of text into a line of the style's comment. Styles without a middle marker don't indent the text
+func (s CommentStyle) commentLine(text string) string {
+	if s.Line != "" {
+		return strings.TrimRight(s.Line+" "+text, " ")
+	}
+
+	if s.Middle != "" {
+		return strings.TrimRight(s.Middle+" "+text, " ")
+	}
+
+	return text
+}
+
+// uncomment returns the text of a comment line without its markers
+func (s CommentStyle) uncomment(line string) string {
+	line = strings.TrimSpace(line)
+
+	if s.Line != "" {
+		return strings.TrimSpace(strings.TrimPrefix(line, s.Line))
+	}
+
+	for _, marker := range []string{s.Start, strings.TrimSpace(s.End), strings.TrimSpace(s.Middle)} {
+		if marker != "" {
+			line = strings.TrimSpace(strings.TrimPrefix(line, marker))
+		}
+	}
+
+	if end := strings.TrimSpace(s.End); end != "" {
+		line = strings.TrimSpace(strings.TrimSuffix(line, end))
+	}
+
+	return line
+}
+
+// HeaderInput contains the information needed to render a source file header.
+type HeaderInput struct {
+	Copyrights
+	Expression string
+}
+
+// Template for the header written at the top of source files
+const HeaderTemplateBody = `{{range .Copyrights}}Copyright {{.Years}} {{.Credit}}
+{{end}}SPDX-License-Identifier: {{.Expression}}`
+
+var HeaderTemplate = template.Must(template.New("Header").Parse(HeaderTemplateBody))
+
+// Header is the short license header written at the top of source files.
+type Header struct {
+	Copyrights Copyrights
+	Expression string
+}
+
+// NewHeader creates the header for source files covered by the License.
+func NewHeader(license *License) Header {
+	return Header{
+		Copyrights: license.copyrights,
+		Expression: license.licenseExpression().String(),
+	}
+}
+
+// Render generates the text of the header, without comment markers.
+func (h Header) Render() (string, error) {
+	var dest bytes.Buffer
+	if err := HeaderTemplate.Execute(&dest, &HeaderInput{Copyrights: h.Copyrights, Expression: h.Expression}); err != nil {
+		return "", err
+	}
+
+	return dest.String(), nil
+}
+
+var (
+	shebangPattern         = regexp.MustCompile(`^#!`)
+	encodingPattern        = regexp.MustCompile(`^#.*coding[:=]`)
+	buildConstraintPattern = regexp.MustCompile(`^//\s*(?:go:build|\+build)\b`)
+	xmlPrologPattern       = regexp.MustCompile(`^<\?xml\b`)
+	doctypePattern         = regexp.MustCompile(`(?i)^<!DOCTYPE\b`)
+	generatedPattern       = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+)
+
+// prologLength returns how many lines at the top of a file must stay ahead of the header:
+// shebangs, Python encoding declarations, Go build constraints, XML declarations and doctypes.
+func prologLength(lines []string) int {
+	end := 0
+
+	for idx, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case idx == 0 && shebangPattern.MatchString(trimmed):
+		case idx <= 1 && encodingPattern.MatchString(trimmed):
+		case buildConstraintPattern.MatchString(trimmed):
+		case xmlPrologPattern.MatchString(trimmed), doctypePattern.MatchString(trimmed):
+		case trimmed == "":
+			// Blank lines only belong to the prolog when more of it follows
+			continue
+		default:
+			return end
+		}
+
+		end = idx + 1
+	}
+
+	return end
+}
+
+// SourceHeader is the license header found in a source file.
+type SourceHeader struct {
+	// StartLine and EndLine are the 1-based lines the header spans, comment markers included
+	StartLine int
+	EndLine   int
+	// Lines is the text of the header without comment markers
+	Lines []string
+}
+
+// leadingComment returns the first comment block after the prolog of a file split into lines
+func leadingComment(lines []string, style CommentStyle) (SourceHeader, bool) {
+	start := prologLength(lines)
+	for start < len(lines) && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+
+	if start >= len(lines) {
+		return SourceHeader{}, false
+	}
+
+	end := start
+	if style.Line != "" {
+		for end < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[end]), style.Line) && !buildConstraintPattern.MatchString(strings.TrimSpace(lines[end])) {
+			end++
+		}
+	} else if opening := strings.TrimSpace(lines[start]); strings.HasPrefix(opening, style.Start) {
+		// The block ends on the line holding the closer, which may be the opening line itself
+		closer := strings.TrimSpace(style.End)
+		end = start + 1
+		if !strings.Contains(opening[len(style.Start):], closer) {
+			for end < len(lines) {
+				end++
+				if strings.Contains(lines[end-1], closer) {
+					break
+				}
+			}
+		}
+	}
+
+	if end == start {
+		return SourceHeader{}, false
+	}
+
+	block := SourceHeader{StartLine: start + 1, EndLine: end}
+	for _, line := range lines[start:end] {
+		text := style.uncomment(line)
+		if text == "" && style.Line == "" {
+			continue
+		}
+
+		block.Lines = append(block.Lines, text)
+	}
+
+	return block, true
+}
+
+// findHeader locates the header in a file split into lines: the first comment block after the
+// prolog, when it holds a copyright line or an SPDX-License-Identifier tag.
+func findHeader(lines []string, style CommentStyle) (SourceHeader, bool) {
+	block, ok := leadingComment(lines, style)
+	if !ok {
+		return SourceHeader{}, false
+	}
+
+	for _, text := range block.Lines {
+		if strings.Contains(text, "SPDX-License-Identifier:") {
+			return block, true
+		}
+		if _, err := ParseCopyright(text); err == nil {
+			return block, true
+		}
+	}
+
+	return SourceHeader{}, false
+}
+
+// FindHeader locates the license header of a source file written in the given comment style.
+func FindHeader(content string, style CommentStyle) (SourceHeader, bool) {
+	return findHeader(strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n"), style)
+}
+
+// HeaderAction is what happened to the header of a source file.
+type HeaderAction int
+
+const (
+	// HEADER_UNCHANGED means the file already had the header
+	HEADER_UNCHANGED HeaderAction = iota + 1
+	// HEADER_ADDED means the file had no header and one was inserted
+	HEADER_ADDED
+	// HEADER_UPDATED means the file had an outdated header that was replaced
+	HEADER_UPDATED
+	// HEADER_SKIPPED means the file was left alone, because it's generated or holds someone else's copyright
+	HEADER_SKIPPED
+)
+
+// String returns the string representation of the header action.
+func (a HeaderAction) String() string {
+	switch a {
+	case HEADER_UNCHANGED:
+		return "UNCHANGED"
+	case HEADER_ADDED:
+		return "ADDED"
+	case HEADER_UPDATED:
+		return "UPDATED"
+	case HEADER_SKIPPED:
+		return "SKIPPED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// holdsOtherCopyright reports whether a header names copyright holders, none of which hold one of the copyrights
+func holdsOtherCopyright(header SourceHeader, copyrights Copyrights) bool {
+	found := false
+
+	for _, line := range header.Lines {
+		copyright, err := ParseCopyright(line)
+		if err != nil {
+			continue
+		}
+
+		if copyrights.index(copyright.Holder) != -1 {
+			return false
+		}
+		found = true
+	}
+
+	return found
+}
+
+// InsertHeader adds the header to the content of a source file, or brings an existing one up to date.
+// The header goes after any shebang, encoding declaration, Go build constraint or XML prolog.
+// Running it again on its own output changes nothing. Generated files, and files whose header names
+// a different copyright holder, are skipped.
+func InsertHeader(content string, header Header, style CommentStyle) (string, HeaderAction, error) {
+	if generatedPattern.MatchString(content) {
+		return content, HEADER_SKIPPED, nil
+	}
+
+	text, err := header.Render()
+	if err != nil {
+		return "", 0, err
+	}
+
+	crlf := strings.Contains(content, "\r\n")
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	wrapped := strings.Split(style.Wrap(text), "\n")
+
+	var updated []string
+	action := HEADER_ADDED
+
+	if existing, ok := findHeader(lines, style); ok {
+		if strings.Join(existing.Lines, "\n") == text {
+			return content, HEADER_UNCHANGED, nil
+		}
+
+		if holdsOtherCopyright(existing, header.Copyrights) {
+			return content, HEADER_SKIPPED, nil
+		}
+
+		updated = append(updated, lines[:existing.StartLine-1]...)
+		updated = append(updated, wrapped...)
+		updated = append(updated, lines[existing.EndLine:]...)
+		action = HEADER_UPDATED
+	} else {
+		prolog := prologLength(lines)
+		rest := lines[prolog:]
+		for len(rest) > 0 && strings.TrimSpace(rest[0]) == "" {
+			rest = rest[1:]
+		}
+
+		updated = append(updated, lines[:prolog]...)
+		if prolog > 0 {
+			updated = append(updated, "")
+		}
+		updated = append(updated, wrapped...)
+
+		if len(rest) == 0 {
+			// Empty files end up with just the header
+			updated = append(updated, "")
+		} else {
+			updated = append(updated, "")
+			updated = append(updated, rest...)
+		}
+	}
+
+	result := strings.Join(updated, "\n")
+	if crlf {
+		result = strings.ReplaceAll(result, "\n", "\r\n")
+	}
+
+	return result, action, nil
+}
+
+// HeaderChange records what happened to the header of one file.
+type HeaderChange struct {
+	Path   string
+	Action HeaderAction
+}
+
+// skippedSourceDirs are never walked for source files
+var skippedSourceDirs = map[string]bool{
+	".git":             true,
+	".hg":              true,
+	".svn":             true,
+	"node_modules":     true,
+	"vendor":           true,
+	REUSE_LICENSES_DIR: true,
+}
+
+// walkSourceFiles calls fn for every file under root with a known comment style
+func walkSourceFiles(root string, fn func(path string, style CommentStyle, entry fs.DirEntry) error) error {
+	return filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if path != root && skippedSourceDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		style, ok := CommentStyleForPath(path)
+		if !ok || !entry.Type().IsRegular() {
+			return nil
+		}
+
+		return fn(path, style, entry)
+	})
+}
+
+// ApplyHeaders adds or updates the header of every source file under root.
+// Returns the files that were changed or skipped, files that already had the header aren't listed.
+func ApplyHeaders(root string, header Header) ([]HeaderChange, error) {
+	return ApplyHeadersFunc(root, func(path string) (Header, error) {
+		return header, nil
+	})
+}
+
+// HeaderFunc returns the header a source file should have.
+type HeaderFunc func(path string) (Header, error)
+
+// ApplyHeadersFunc is like ApplyHeaders, with a header of its own for each file, e.g. with the years of its history.
+func ApplyHeadersFunc(root string, headerFor HeaderFunc) ([]HeaderChange, error) {
+	var changes []HeaderChange
+
+	err := walkSourceFiles(root, func(path string, style CommentStyle, entry fs.DirEntry) error {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		header, err := headerFor(path)
+		if err != nil {
+			return err
+		}
+
+		updated, action, err := InsertHeader(string(content), header, style)
+		if err != nil {
+			return err
+		}
+
+		if action == HEADER_UNCHANGED {
+			return nil
+		}
+
+		changes = append(changes, HeaderChange{Path: path, Action: action})
+		if action == HEADER_SKIPPED {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(path, []byte(updated), info.Mode().Perm())
+	})
+
+	return changes, err
+}
+
+// ModifiedYearFunc returns the year a file was last changed.
+type ModifiedYearFunc func(path string) (int, error)
+
+// FileModifiedYear returns the year of a file's modification time.
+func FileModifiedYear(path string) (int, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.ModTime().Year(), nil
+}
+
+var headerYearsPattern = regexp.MustCompile(yearsPattern)
+
+// BumpHeaderYear extends the holder's copyright in the header of a source file to the given year,
+// leaving the rest of the header as it is. Returns whether the content changed.
+func BumpHeaderYear(content string, holder string, year int, style CommentStyle) (string, bool) {
+	crlf := strings.Contains(content, "\r\n")
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	header, ok := findHeader(lines, style)
+	if !ok {
+		return content, false
+	}
+
+	changed := false
+	for idx := header.StartLine - 1; idx < header.EndLine; idx++ {
+		copyright, err := ParseCopyright(style.uncomment(lines[idx]))
+		if err != nil || copyright.Holder != holder {
+			continue
+		}
+
+		// Copyrights starting after the year are left alone
+		if _, err = copyright.BumpYear(year); err != nil {
+			continue
+		}
+
+		// Only the years are rewritten, the line keeps its comment markers and wording
+		loc := headerYearsPattern.FindStringIndex(lines[idx])
+		updated := lines[idx][:loc[0]] + copyright.Years().String() + lines[idx][loc[1]:]
+
+		if updated != lines[idx] {
+			lines[idx] = updated
+			changed = true
+		}
+	}
+
+	if !changed {
+		return content, false
+	}
+
+	result := strings.Join(lines, "\n")
+	if crlf {
+		result = strings.ReplaceAll(result, "\n", "\r\n")
+	}
+
+	return result, true
+}
+
+// BumpHeaderYears extends the holder's copyright in the header of every source file under root to the given year.
+// Files modifiedYear reports as not changed during that year are skipped. Returns the files that were updated.
+func BumpHeaderYears(root string, holder string, year int, modifiedYear ModifiedYearFunc) ([]HeaderChange, error) {
+	var changes []HeaderChange
+
+	err := walkSourceFiles(root, func(path string, style CommentStyle, entry fs.DirEntry) error {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		updated, changed := BumpHeaderYear(string(content), holder, year, style)
+		if !changed {
+			return nil
+		}
+
+		modified, err := modifiedYear(path)
+		if err != nil {
+			return err
+		}
+
+		if modified != year {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		if err = os.WriteFile(path, []byte(updated), info.Mode().Perm()); err != nil {
+			return err
+		}
+
+		changes = append(changes, HeaderChange{Path: path, Action: HEADER_UPDATED})
+
+		return nil
+	})
+
+	return changes, err
+}
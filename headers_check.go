@@ -0,0 +1,235 @@
+package ligen
+
+import (
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+)
+
+// HeaderProblem is what's wrong with the header of a source file.
+type HeaderProblem int
+
+const (
+	// HEADER_MISSING means the file has no license header
+	HEADER_MISSING HeaderProblem = iota + 1
+	// HEADER_STALE means the header names the wrong copyright years or holder
+	HEADER_STALE
+	// HEADER_WRONG_LICENSE means the header is for a different license than the project's
+	HEADER_WRONG_LICENSE
+	// HEADER_MALFORMED means the header can't be parsed
+	HEADER_MALFORMED
+)
+
+// String returns the string representation of the header problem.
+func (p HeaderProblem) String() string {
+	switch p {
+	case HEADER_MISSING:
+		return "MISSING"
+	case HEADER_STALE:
+		return "STALE"
+	case HEADER_WRONG_LICENSE:
+		return "WRONG_LICENSE"
+	case HEADER_MALFORMED:
+		return "MALFORMED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// HeaderFinding is a problem found in the header of a source file.
+type HeaderFinding struct {
+	Path string
+	// Line is the 1-based line the problem is on, or where the header belongs when it's missing
+	Line    int
+	Problem HeaderProblem
+	// SuggestedFix is the commented text that should replace the line, or be inserted when the header is missing
+	SuggestedFix string
+}
+
+// mentionsLicensing reports whether a comment block looks like it was meant as a license header
+func mentionsLicensing(block SourceHeader) bool {
+	for _, line := range block.Lines {
+		lowered := strings.ToLower(line)
+		if strings.Contains(lowered, "copyright") || strings.Contains(lowered, "license") || strings.Contains(line, "©") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckHeader checks the content of a source file against the header it should have, without changing it.
+// Returns no findings when the header is up to date, or when the file is generated.
+// Headers without an SPDX-License-Identifier tag are identified by matching their text against the known licenses.
+func CheckHeader(content string, header Header, style CommentStyle) ([]HeaderFinding, error) {
+	if generatedPattern.MatchString(content) {
+		return nil, nil
+	}
+
+	text, err := header.Render()
+	if err != nil {
+		return nil, err
+	}
+
+	// The header renders a line per copyright, in order, followed by the SPDX tag
+	rendered := strings.Split(text, "\n")
+	expectedTag := rendered[len(rendered)-1]
+	expectedCopyrights := rendered[:len(rendered)-1]
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	block, ok := leadingComment(lines, style)
+	if !ok || !mentionsLicensing(block) {
+		return []HeaderFinding{{Line: prologLength(lines) + 1, Problem: HEADER_MISSING, SuggestedFix: style.Wrap(text)}}, nil
+	}
+
+	expectedExpression, err := NormalizeExpression(header.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copyrights the header should have but the block doesn't name the holder of,
+	// they're suggested in place of malformed lines and lines naming someone else
+	present := make(map[string]bool)
+	for _, line := range block.Lines {
+		if copyright, err := ParseCopyright(line); err == nil {
+			present[copyright.Holder] = true
+		}
+	}
+
+	var missing []string
+	for idx, copyright := range header.Copyrights {
+		if !present[copyright.Holder] {
+			missing = append(missing, expectedCopyrights[idx])
+		}
+	}
+
+	nextMissing := func() (string, bool) {
+		if len(missing) == 0 {
+			return "", false
+		}
+
+		next := missing[0]
+		missing = missing[1:]
+
+		return next, true
+	}
+
+	var findings []HeaderFinding
+	foundLicense := false
+	lastCopyright := -1
+
+	for idx := block.StartLine - 1; idx < block.EndLine; idx++ {
+		line := style.uncomment(lines[idx])
+		lowered := strings.ToLower(line)
+
+		switch {
+		case strings.Contains(line, "SPDX-License-Identifier:"):
+			foundLicense = true
+
+			expression, err := ParseLicenseIdentifier(line)
+			if err != nil {
+				findings = append(findings, HeaderFinding{Line: idx + 1, Problem: HEADER_MALFORMED, SuggestedFix: style.commentLine(expectedTag)})
+				continue
+			}
+
+			if expression.Normalize().String() != expectedExpression {
+				findings = append(findings, HeaderFinding{Line: idx + 1, Problem: HEADER_WRONG_LICENSE, SuggestedFix: style.commentLine(expectedTag)})
+			}
+		case strings.HasPrefix(lowered, "copyright") || looksLikeCopyright(line):
+			lastCopyright = idx
+
+			copyright, err := ParseCopyright(line)
+			if err != nil {
+				fix, ok := nextMissing()
+				if !ok && len(expectedCopyrights) > 0 {
+					fix = expectedCopyrights[0]
+				}
+
+				findings = append(findings, HeaderFinding{Line: idx + 1, Problem: HEADER_MALFORMED, SuggestedFix: style.commentLine(fix)})
+				continue
+			}
+
+			holder := header.Copyrights.index(copyright.Holder)
+			if holder == -1 {
+				// Holders beyond the ones the header names are left alone,
+				// unless one of those is missing and this line likely stands in for it
+				if fix, ok := nextMissing(); ok {
+					findings = append(findings, HeaderFinding{Line: idx + 1, Problem: HEADER_STALE, SuggestedFix: style.commentLine(fix)})
+				}
+				continue
+			}
+
+			if !copyright.Equal(header.Copyrights[holder]) {
+				findings = append(findings, HeaderFinding{Line: idx + 1, Problem: HEADER_STALE, SuggestedFix: style.commentLine(expectedCopyrights[holder])})
+			}
+		}
+	}
+
+	switch {
+	case lastCopyright == -1:
+		fix := make([]string, 0, len(expectedCopyrights))
+		for _, copyright := range expectedCopyrights {
+			fix = append(fix, style.commentLine(copyright))
+		}
+
+		findings = append(findings, HeaderFinding{Line: block.StartLine, Problem: HEADER_MALFORMED, SuggestedFix: strings.Join(fix, "\n")})
+	case len(missing) > 0:
+		// The remaining copyrights go after the last copyright line
+		fix := []string{lines[lastCopyright]}
+		for _, copyright := range missing {
+			fix = append(fix, style.commentLine(copyright))
+		}
+
+		findings = append(findings, HeaderFinding{Line: lastCopyright + 1, Problem: HEADER_STALE, SuggestedFix: strings.Join(fix, "\n")})
+	}
+
+	// Headers that spell out the license instead of tagging it, like the Apache boilerplate
+	if !foundLicense {
+		text := strings.Join(block.Lines, "\n")
+		licenseType, err := Match(text, 0.90)
+		// Match reports the "only" variant, the boilerplate says whether "or later" applies
+		licenseType = resolveVersionChoice(licenseType, text)
+
+		switch {
+		case err != nil:
+			findings = append(findings, HeaderFinding{Line: block.EndLine, Problem: HEADER_MALFORMED, SuggestedFix: style.commentLine(expectedTag)})
+		case licenseType.SPDXID() != expectedExpression:
+			findings = append(findings, HeaderFinding{Line: block.StartLine, Problem: HEADER_WRONG_LICENSE, SuggestedFix: style.commentLine(expectedTag)})
+		}
+	}
+
+	slices.SortStableFunc(findings, func(a, b HeaderFinding) int {
+		return a.Line - b.Line
+	})
+
+	return findings, nil
+}
+
+// CheckHeaders checks the header of every source file under root, without changing any of them.
+// Findings are ordered by path, then line.
+func CheckHeaders(root string, header Header) ([]HeaderFinding, error) {
+	var findings []HeaderFinding
+
+	err := walkSourceFiles(root, func(path string, style CommentStyle, entry fs.DirEntry) error {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		fileFindings, err := CheckHeader(string(content), header, style)
+		if err != nil {
+			return err
+		}
+
+		for _, finding := range fileFindings {
+			finding.Path = path
+			findings = append(findings, finding)
+		}
+
+		return nil
+	})
+
+	return findings, err
+}
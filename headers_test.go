@@ -0,0 +1,658 @@
+package ligen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCommentStyleForPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected CommentStyle
+		ok       bool
+	}{
+		{path: "main.go", expected: SlashCommentStyle, ok: true},
+		{path: "src/App.TS", expected: SlashCommentStyle, ok: true},
+		{path: "script.py", expected: HashCommentStyle, ok: true},
+		{path: "deploy/values.yaml", expected: HashCommentStyle, ok: true},
+		{path: "build/Makefile", expected: HashCommentStyle, ok: true},
+		{path: "style.css", expected: CBlockCommentStyle, ok: true},
+		{path: "pom.xml", expected: MarkupCommentStyle, ok: true},
+		{path: "image.png", expected: CommentStyle{}, ok: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			style, ok := CommentStyleForPath(tc.path)
+
+			if ok != tc.ok {
+				t.Errorf("Expected %t, got %t", tc.ok, ok)
+			}
+
+			if style != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, style)
+			}
+		})
+	}
+}
+
+func TestInsertHeader(t *testing.T) {
+	header := Header{
+		Copyrights: Copyrights{{Holder: "Acme", StartYear: 2024}},
+		Expression: "Apache-2.0",
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		content        string
+		expected       string
+		expectedAction HeaderAction
+	}{
+		{
+			name:           "Pass-Go",
+			path:           "main.go",
+			content:        "package main\n",
+			expected:       "// Copyright 2024 Acme\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n",
+			expectedAction: HEADER_ADDED,
+		},
+		{
+			name:           "Pass-Go-BuildConstraint",
+			path:           "unix.go",
+			content:        "//go:build linux || darwin\n// +build linux darwin\n\n// Package unix does unix things.\npackage unix\n",
+			expected:       "//go:build linux || darwin\n// +build linux darwin\n\n// Copyright 2024 Acme\n// SPDX-License-Identifier: Apache-2.0\n\n// Package unix does unix things.\npackage unix\n",
+			expectedAction: HEADER_ADDED,
+		},
+		{
+			name:           "Pass-Python-Shebang",
+			path:           "script.py",
+			content:        "#!/usr/bin/env python3\n# -*- coding: utf-8 -*-\nprint('hi')\n",
+			expected:       "#!/usr/bin/env python3\n# -*- coding: utf-8 -*-\n\n# Copyright 2024 Acme\n# SPDX-License-Identifier: Apache-2.0\n\nprint('hi')\n",
+			expectedAction: HEADER_ADDED,
+		},
+		{
+			name:           "Pass-TypeScript",
+			path:           "index.ts",
+			content:        "\n\nexport const x = 1;\n",
+			expected:       "// Copyright 2024 Acme\n// SPDX-License-Identifier: Apache-2.0\n\nexport const x = 1;\n",
+			expectedAction: HEADER_ADDED,
+		},
+		{
+			name:           "Pass-Shell",
+			path:           "run.sh",
+			content:        "#!/bin/sh\necho hi\n",
+			expected:       "#!/bin/sh\n\n# Copyright 2024 Acme\n# SPDX-License-Identifier: Apache-2.0\n\necho hi\n",
+			expectedAction: HEADER_ADDED,
+		},
+		{
+			name:           "Pass-YAML",
+			path:           "values.yaml",
+			content:        "---\nname: ligen\n",
+			expected:       "# Copyright 2024 Acme\n# SPDX-License-Identifier: Apache-2.0\n\n---\nname: ligen\n",
+			expectedAction: HEADER_ADDED,
+		},
+		{
+			name:           "Pass-XML-Prolog",
+			path:           "pom.xml",
+			content:        "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<project/>\n",
+			expected:       "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n\n<!--\nCopyright 2024 Acme\nSPDX-License-Identifier: Apache-2.0\n-->\n\n<project/>\n",
+			expectedAction: HEADER_ADDED,
+		},
+		{
+			name:           "Pass-CSS",
+			path:           "style.css",
+			content:        "body {}\n",
+			expected:       "/*\n * Copyright 2024 Acme\n * SPDX-License-Identifier: Apache-2.0\n */\n\nbody {}\n",
+			expectedAction: HEADER_ADDED,
+		},
+		{
+			name:           "Pass-EmptyFile",
+			path:           "empty.go",
+			content:        "",
+			expected:       "// Copyright 2024 Acme\n// SPDX-License-Identifier: Apache-2.0\n",
+			expectedAction: HEADER_ADDED,
+		},
+		{
+			name:           "Pass-CRLF",
+			path:           "main.go",
+			content:        "package main\r\n",
+			expected:       "// Copyright 2024 Acme\r\n// SPDX-License-Identifier: Apache-2.0\r\n\r\npackage main\r\n",
+			expectedAction: HEADER_ADDED,
+		},
+		{
+			name:           "Pass-Unchanged",
+			path:           "main.go",
+			content:        "// Copyright 2024 Acme\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n",
+			expected:       "// Copyright 2024 Acme\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n",
+			expectedAction: HEADER_UNCHANGED,
+		},
+		{
+			name:           "Pass-HeaderAboveBuildConstraint",
+			path:           "unix.go",
+			content:        "// Copyright 2022 Acme\n// SPDX-License-Identifier: MIT\n\n//go:build linux\n\npackage unix\n",
+			expected:       "// Copyright 2024 Acme\n// SPDX-License-Identifier: Apache-2.0\n\n//go:build linux\n\npackage unix\n",
+			expectedAction: HEADER_UPDATED,
+		},
+		{
+			name:           "Pass-Updated-Block",
+			path:           "style.css",
+			content:        "/*\n * Copyright 2020-2023 Acme\n * SPDX-License-Identifier: MIT\n */\n\nbody {}\n",
+			expected:       "/*\n * Copyright 2024 Acme\n * SPDX-License-Identifier: Apache-2.0\n */\n\nbody {}\n",
+			expectedAction: HEADER_UPDATED,
+		},
+		{
+			name:           "Pass-Skipped-OtherHolder",
+			path:           "vendored.py",
+			content:        "# Copyright 2019 Someone Else\n# SPDX-License-Identifier: BSD-3-Clause\nimport os\n",
+			expected:       "# Copyright 2019 Someone Else\n# SPDX-License-Identifier: BSD-3-Clause\nimport os\n",
+			expectedAction: HEADER_SKIPPED,
+		},
+		{
+			name:           "Pass-Skipped-Generated",
+			path:           "zz_generated.go",
+			content:        "// Code generated by stringer. DO NOT EDIT.\n\npackage main\n",
+			expected:       "// Code generated by stringer. DO NOT EDIT.\n\npackage main\n",
+			expectedAction: HEADER_SKIPPED,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			style, ok := CommentStyleForPath(tc.path)
+			if !ok {
+				t.Fatalf("Expected a comment style for %s", tc.path)
+			}
+
+			content, action, err := InsertHeader(tc.content, header, style)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if action != tc.expectedAction {
+				t.Errorf("Expected action %s, got %s", tc.expectedAction, action)
+			}
+
+			if content != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, content)
+			}
+
+			// Running it again never changes the file
+			again, _, err := InsertHeader(content, header, style)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if again != content {
+				t.Errorf("Expected a second run to leave %q alone, got %q", content, again)
+			}
+		})
+	}
+}
+
+func TestApplyHeaders(t *testing.T) {
+	// GIVEN
+	root := t.TempDir()
+	files := map[string]string{
+		"main.go":                 "package main\n",
+		"scripts/run.sh":          "#!/bin/sh\necho hi\n",
+		"current.go":              "// Copyright 2024 Acme\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+		"vendor/lib/lib.go":       "package lib\n",
+		"node_modules/x/index.js": "module.exports = {}\n",
+		"README.txt":              "no comment style\n",
+	}
+
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	license, err := New("Ligen", "Acme", 2024, 0, MIT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// WHEN
+	changes, err := ApplyHeaders(root, NewHeader(license))
+
+	// THEN
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []HeaderChange{
+		{Path: filepath.Join(root, "main.go"), Action: HEADER_ADDED},
+		{Path: filepath.Join(root, "scripts/run.sh"), Action: HEADER_ADDED},
+	}
+
+	if !reflect.DeepEqual(expected, changes) {
+		t.Errorf("Expected %+v, got %+v", expected, changes)
+	}
+
+	for _, path := range []string{"vendor/lib/lib.go", "node_modules/x/index.js", "README.txt", "current.go"} {
+		content, err := os.ReadFile(filepath.Join(root, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(content) != files[path] {
+			t.Errorf("Expected %s to be left alone, got %q", path, content)
+		}
+	}
+
+	// A second run has nothing left to do
+	changes, err = ApplyHeaders(root, NewHeader(license))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", changes)
+	}
+}
+
+func TestCheckHeader(t *testing.T) {
+	header := Header{
+		Copyrights: Copyrights{{Holder: "Acme", StartYear: 2024}},
+		Expression: "MIT OR Apache-2.0",
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		expected []HeaderFinding
+	}{
+		{
+			name:     "Pass-UpToDate",
+			path:     "main.go",
+			content:  "// Copyright 2024 Acme\n// SPDX-License-Identifier: MIT OR Apache-2.0\n\npackage main\n",
+			expected: nil,
+		},
+		{
+			name:     "Pass-EquivalentExpression",
+			path:     "main.go",
+			content:  "// Copyright 2024-2024 Acme\n// SPDX-License-Identifier: mit or Apache-2.0 or MIT\n\npackage main\n",
+			expected: nil,
+		},
+		{
+			name:     "Pass-Generated",
+			path:     "zz_generated.go",
+			content:  "// Code generated by stringer. DO NOT EDIT.\n\npackage main\n",
+			expected: nil,
+		},
+		{
+			name:    "Fail-Missing",
+			path:    "run.sh",
+			content: "#!/bin/sh\n# Runs the thing\necho hi\n",
+			expected: []HeaderFinding{
+				{Line: 2, Problem: HEADER_MISSING, SuggestedFix: "# Copyright 2024 Acme\n# SPDX-License-Identifier: MIT OR Apache-2.0"},
+			},
+		},
+		{
+			name:    "Fail-Stale",
+			path:    "main.go",
+			content: "// Copyright 2020-2023 Acme\n// SPDX-License-Identifier: MIT OR Apache-2.0\n\npackage main\n",
+			expected: []HeaderFinding{
+				{Line: 1, Problem: HEADER_STALE, SuggestedFix: "// Copyright 2024 Acme"},
+			},
+		},
+		{
+			name:    "Fail-WrongHolder",
+			path:    "style.css",
+			content: "/*\n * Copyright 2024 Someone Else\n * SPDX-License-Identifier: MIT OR Apache-2.0\n */\nbody {}\n",
+			expected: []HeaderFinding{
+				{Line: 2, Problem: HEADER_STALE, SuggestedFix: " * Copyright 2024 Acme"},
+			},
+		},
+		{
+			name:    "Fail-WrongLicense",
+			path:    "main.py",
+			content: "# Copyright 2024 Acme\n# SPDX-License-Identifier: GPL-3.0-only\nimport os\n",
+			expected: []HeaderFinding{
+				{Line: 2, Problem: HEADER_WRONG_LICENSE, SuggestedFix: "# SPDX-License-Identifier: MIT OR Apache-2.0"},
+			},
+		},
+		{
+			name: "Fail-WrongLicense-Boilerplate",
+			path: "Main.java",
+			content: `// Copyright 2024 Acme
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+
+class Main {}
+`,
+			expected: []HeaderFinding{
+				{Line: 1, Problem: HEADER_WRONG_LICENSE, SuggestedFix: "// SPDX-License-Identifier: MIT OR Apache-2.0"},
+			},
+		},
+		{
+			name:    "Fail-Malformed",
+			path:    "main.ts",
+			content: "// Copyright Acme, all years\n// SPDX-License-Identifier: MIT OR OR\n\nexport {}\n",
+			expected: []HeaderFinding{
+				{Line: 1, Problem: HEADER_MALFORMED, SuggestedFix: "// Copyright 2024 Acme"},
+				{Line: 2, Problem: HEADER_MALFORMED, SuggestedFix: "// SPDX-License-Identifier: MIT OR Apache-2.0"},
+			},
+		},
+		{
+			name:    "Fail-OrderedByLine",
+			path:    "main.go",
+			content: "// Licensing notes\n// SPDX-License-Identifier: GPL-3.0-only\n\npackage main\n",
+			expected: []HeaderFinding{
+				{Line: 1, Problem: HEADER_MALFORMED, SuggestedFix: "// Copyright 2024 Acme"},
+				{Line: 2, Problem: HEADER_WRONG_LICENSE, SuggestedFix: "// SPDX-License-Identifier: MIT OR Apache-2.0"},
+			},
+		},
+		{
+			name:    "Fail-Malformed-NoCopyright",
+			path:    "main.go",
+			content: "// SPDX-License-Identifier: MIT OR Apache-2.0\n\npackage main\n",
+			expected: []HeaderFinding{
+				{Line: 1, Problem: HEADER_MALFORMED, SuggestedFix: "// Copyright 2024 Acme"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			style, ok := CommentStyleForPath(tc.path)
+			if !ok {
+				t.Fatalf("Expected a comment style for %s", tc.path)
+			}
+
+			findings, err := CheckHeader(tc.content, header, style)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, findings) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, findings)
+			}
+		})
+	}
+}
+
+func TestCheckHeaderOrLaterText(t *testing.T) {
+	var notice bytes.Buffer
+	if err := GnuGeneral3OrLaterNoticeTemplate.Execute(&notice, &NoticeInput{ProjectName: "Ligen", Copyrights: Copyrights{{Holder: "Acme", StartYear: 2024}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The notice, copyright included, followed by the license text, spelled out in the header
+	content := SlashCommentStyle.Wrap(notice.String()+"\n"+GNUGeneral3LicenseBody) + "\n\npackage main\n"
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   bool
+	}{
+		{name: "Pass-OrLater", expression: "GPL-3.0-or-later", expected: false},
+		{name: "Fail-Only", expression: "GPL-3.0-only", expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			header := Header{Copyrights: Copyrights{{Holder: "Acme", StartYear: 2024}}, Expression: tc.expression}
+
+			findings, err := CheckHeader(content, header, SlashCommentStyle)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wrongLicense := slices.ContainsFunc(findings, func(f HeaderFinding) bool {
+				return f.Problem == HEADER_WRONG_LICENSE
+			})
+
+			if wrongLicense != tc.expected {
+				t.Errorf("Expected a wrong license finding to be %t, got %+v", tc.expected, findings)
+			}
+		})
+	}
+}
+
+func TestCheckHeaderMultipleHolders(t *testing.T) {
+	header := Header{
+		Copyrights: Copyrights{
+			{Holder: "Acme", StartYear: 2024},
+			{Holder: "Globex", StartYear: 2021, EndYear: 2024},
+		},
+		Expression: "MIT",
+	}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected []HeaderFinding
+	}{
+		{
+			name:     "Pass-UpToDate",
+			content:  "// Copyright 2024 Acme\n// Copyright 2021-2024 Globex\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+			expected: nil,
+		},
+		{
+			name:     "Pass-OtherContributor",
+			content:  "// Copyright 2024 Acme\n// Copyright 2021-2024 Globex\n// Copyright 2023 Initech\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+			expected: nil,
+		},
+		{
+			name:    "Fail-MissingHolder",
+			content: "// Copyright 2024 Acme\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+			expected: []HeaderFinding{
+				{Line: 1, Problem: HEADER_STALE, SuggestedFix: "// Copyright 2024 Acme\n// Copyright 2021-2024 Globex"},
+			},
+		},
+		{
+			name:    "Fail-StaleSecondHolder",
+			content: "// Copyright 2024 Acme\n// Copyright 2021 Globex\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+			expected: []HeaderFinding{
+				{Line: 2, Problem: HEADER_STALE, SuggestedFix: "// Copyright 2021-2024 Globex"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			findings, err := CheckHeader(tc.content, header, SlashCommentStyle)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, findings) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, findings)
+			}
+		})
+	}
+
+	// Inserting the header writes a line per holder, and files naming any of them are updated
+	updated, action, err := InsertHeader("// Copyright 2021 Globex\n\npackage main\n", header, SlashCommentStyle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "// Copyright 2024 Acme\n// Copyright 2021-2024 Globex\n// SPDX-License-Identifier: MIT\n\npackage main\n"
+	if updated != expected || action != HEADER_UPDATED {
+		t.Errorf("Expected %q (%s), got %q (%s)", expected, HEADER_UPDATED, updated, action)
+	}
+}
+
+func TestCheckHeaders(t *testing.T) {
+	// GIVEN
+	root := t.TempDir()
+	files := map[string]string{
+		"main.go":    "package main\n",
+		"current.go": "// Copyright 2024 Acme\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+		"old.py":     "# Copyright 2022 Acme\n# SPDX-License-Identifier: MIT\n",
+	}
+
+	for path, content := range files {
+		if err := os.WriteFile(filepath.Join(root, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	header := Header{Copyrights: Copyrights{{Holder: "Acme", StartYear: 2024}}, Expression: "MIT"}
+
+	// WHEN
+	findings, err := CheckHeaders(root, header)
+
+	// THEN
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []HeaderFinding{
+		{Path: filepath.Join(root, "main.go"), Line: 1, Problem: HEADER_MISSING, SuggestedFix: "// Copyright 2024 Acme\n// SPDX-License-Identifier: MIT"},
+		{Path: filepath.Join(root, "old.py"), Line: 1, Problem: HEADER_STALE, SuggestedFix: "# Copyright 2024 Acme"},
+	}
+
+	if !reflect.DeepEqual(expected, findings) {
+		t.Errorf("Expected %+v, got %+v", expected, findings)
+	}
+
+	// Checking never touches the files
+	for path, content := range files {
+		current, err := os.ReadFile(filepath.Join(root, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(current) != content {
+			t.Errorf("Expected %s to be left alone, got %q", path, current)
+		}
+	}
+}
+
+func TestBumpHeaderYear(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		content         string
+		expected        string
+		expectedChanged bool
+	}{
+		{
+			name:            "Pass-Extend",
+			path:            "main.go",
+			content:         "// Copyright 2020 Acme\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+			expected:        "// Copyright 2020-2025 Acme\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+			expectedChanged: true,
+		},
+		{
+			name:            "Pass-KeepsWording",
+			path:            "style.css",
+			content:         "/*\n * Copyright (c) 2020-2024 Acme\n */\nbody {}\n",
+			expected:        "/*\n * Copyright (c) 2020-2025 Acme\n */\nbody {}\n",
+			expectedChanged: true,
+		},
+		{
+			name:            "Pass-Collapse",
+			path:            "run.sh",
+			content:         "#!/bin/sh\n# Copyright 2025-2025 Acme\necho hi\n",
+			expected:        "#!/bin/sh\n# Copyright 2025 Acme\necho hi\n",
+			expectedChanged: true,
+		},
+		{
+			name:            "Pass-YearList",
+			path:            "main.go",
+			content:         "// Copyright 2018, 2020-2022 Acme\n\npackage main\n",
+			expected:        "// Copyright 2018, 2020-2025 Acme\n\npackage main\n",
+			expectedChanged: true,
+		},
+		{
+			name:            "Pass-OtherHolder",
+			path:            "vendored.py",
+			content:         "# Copyright 2019 Someone Else\nimport os\n",
+			expected:        "# Copyright 2019 Someone Else\nimport os\n",
+			expectedChanged: false,
+		},
+		{
+			name:            "Pass-NoHeader",
+			path:            "main.go",
+			content:         "package main\n",
+			expected:        "package main\n",
+			expectedChanged: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			style, ok := CommentStyleForPath(tc.path)
+			if !ok {
+				t.Fatalf("Expected a comment style for %s", tc.path)
+			}
+
+			content, changed := BumpHeaderYear(tc.content, "Acme", 2025, style)
+
+			if changed != tc.expectedChanged {
+				t.Errorf("Expected changed %t, got %t", tc.expectedChanged, changed)
+			}
+
+			if content != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, content)
+			}
+		})
+	}
+}
+
+func TestBumpHeaderYears(t *testing.T) {
+	// GIVEN
+	root := t.TempDir()
+	files := map[string]string{
+		"new.go":   "// Copyright 2020 Acme\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+		"old.go":   "// Copyright 2020 Acme\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+		"plain.go": "package main\n",
+	}
+
+	for path, content := range files {
+		if err := os.WriteFile(filepath.Join(root, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	modifiedYear := func(path string) (int, error) {
+		if filepath.Base(path) == "old.go" {
+			return 2023, nil
+		}
+		return 2025, nil
+	}
+
+	// WHEN
+	changes, err := BumpHeaderYears(root, "Acme", 2025, modifiedYear)
+
+	// THEN
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedChanges := []HeaderChange{{Path: filepath.Join(root, "new.go"), Action: HEADER_UPDATED}}
+	if !reflect.DeepEqual(expectedChanges, changes) {
+		t.Errorf("Expected %+v, got %+v", expectedChanges, changes)
+	}
+
+	expected := map[string]string{
+		"new.go":   "// Copyright 2020-2025 Acme\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+		"old.go":   files["old.go"],
+		"plain.go": files["plain.go"],
+	}
+
+	for path, content := range expected {
+		current, err := os.ReadFile(filepath.Join(root, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(current) != content {
+			t.Errorf("Expected %s to be %q, got %q", path, content, current)
+		}
+	}
+}
@@ -3,121 +3,212 @@ package ligen
 import (
 	"bytes"
 	"errors"
+	"net/mail"
+	"slices"
 	"strings"
+	"text/template"
 	"time"
 )
 
 // NoticeInput contains the information needed to generate a NOTICE file.
 type NoticeInput struct {
 	ProjectName string
-	Holder      string
-	StartYear   int
-	EndYear     int
+	Copyrights
+	SecondaryLicense string
+}
+
+// GNUGeneral2SecondaryLicense is the Secondary License most Eclipse Public License 2.0 projects declare.
+const GNUGeneral2SecondaryLicense = "GNU General Public License, version 2 with the GNU Classpath Exception which is available at https://www.gnu.org/software/classpath/license.html"
+
+// Parameters contains license specific values that go beyond the copyright and project name.
+// Fields that don't apply to a license type are ignored when it's rendered.
+type Parameters struct {
+	// SecondaryLicense names the license(s) an Eclipse Public License 2.0 project may also be
+	// made available under. Empty means no Secondary License is declared.
+	SecondaryLicense string
+
+	// Licensor, LicensedWork, AdditionalUseGrant, ChangeDate and ChangeLicense are the parameters of
+	// the Business Source License 1.1. When empty, Licensor defaults to the copyright holder,
+	// LicensedWork to the project name and AdditionalUseGrant to "None".
+	Licensor           string
+	LicensedWork       string
+	AdditionalUseGrant string
+	ChangeDate         time.Time
+	ChangeLicense      string
+
+	// ConfidentialityClause and ContactEmail configure a proprietary license, either
+	// can be left empty to leave it out.
+	ConfidentialityClause string
+	ContactEmail          string
+}
+
+// BusinessSourceInput contains the information needed to render a Business Source License.
+type BusinessSourceInput struct {
+	Copyrights
+	Licensor           string
+	LicensedWork       string
+	AdditionalUseGrant string
+	ChangeDate         time.Time
+	ChangeLicense      string
+}
+
+// ProprietaryInput contains the information needed to render a proprietary license.
+type ProprietaryInput struct {
+	Copyrights
+	ConfidentialityClause string
+	ContactEmail          string
 }
 
 // General use copyright line
 var (
-	StartYearTooOldError        = errors.New("start year cannot be more than 50 years in the past")
-	StartYearTooNewError        = errors.New("start year cannot be in the future")
-	EndYearTooOldError          = errors.New("end year cannot be in the past")
-	EndYearBeforeStartError     = errors.New("end year must be after start year")
-	EmptyHolderError            = errors.New("holder must not be empty")
-	HolderTooLongError          = errors.New("holder must be less than 128 chars")
-	EmptyNameError              = errors.New("name must not be empty")
-	NameTooLongError            = errors.New("name must be 128 chars")
-	NameTooShortError           = errors.New("project name must have at least 1 character")
-	InvalidLicenseType          = errors.New("invalid license type")
-	UnsupportedLicenseTypeError = errors.New("unsupported license type")
-	NoKnownTemplateError        = errors.New("no template found")
+	StartYearTooOldError         = errors.New("start year cannot be more than 50 years in the past")
+	StartYearTooNewError         = errors.New("start year cannot be in the future")
+	EndYearTooOldError           = errors.New("end year cannot be in the past")
+	EndYearTooNewError           = errors.New("end year cannot be in the future")
+	EndYearBeforeStartError      = errors.New("end year must be after start year")
+	EmptyHolderError             = errors.New("holder must not be empty")
+	HolderTooLongError           = errors.New("holder must be less than 128 chars")
+	EmptyNameError               = errors.New("name must not be empty")
+	NameTooLongError             = errors.New("name must be 128 chars")
+	NameTooShortError            = errors.New("project name must have at least 1 character")
+	InvalidLicenseType           = errors.New("invalid license type")
+	UnsupportedLicenseTypeError  = errors.New("unsupported license type")
+	NoKnownTemplateError         = errors.New("no template found")
+	SecondaryLicenseTooLongError = errors.New("secondary license must be less than 256 chars")
+	MissingChangeDateError       = errors.New("change date must be set")
+	MissingChangeLicenseError    = errors.New("change license must be set")
+	InvalidChangeDateError       = errors.New("change date must be formatted as YYYY-MM-DD")
+	InvalidContactEmailError     = errors.New("contact email must be a valid email address")
+	DuplicateHolderError         = errors.New("holder already has a copyright")
+	HolderNotFoundError          = errors.New("holder not found")
+	LastHolderError              = errors.New("cannot remove the only copyright holder")
 )
 
 const (
 	// MAX_NAME_LENGTH is the maximum amount of chars the holder of a copyright can contain
 	// 128 picked arbitrarily, seemed reasonable
 	MAX_NAME_LENGTH = 128
+	// MAX_SECONDARY_LICENSE_LENGTH is the maximum amount of chars a secondary license declaration can contain
+	// long enough to name a license along with its exceptions and where to find them
+	MAX_SECONDARY_LICENSE_LENGTH = 256
 	// MAX_YEARS_PAST is the maximum amount of time in years that a copyright can be backdated
 	// 50 picked arbitrarily, seemed reasonable
 	MAX_YEARS_PAST = 50
 )
 
 // Copyright contains copyright information used to render license notices and files.
+// StartYear and EndYear hold the first and last year it covers, copyrights that cover
+// more than a single range, e.g. "2018, 2020-2022, 2024", keep the full list of years as well.
 type Copyright struct {
 	Holder    string
 	StartYear int
 	EndYear   int
+	// Email is the holder's email, written in angle brackets after their name
+	Email string
+	// Contributors credits the holder's contributors as well, written as "and contributors" after their name
+	Contributors bool
+	// set holds the years when they aren't a single range, or the range runs to the present
+	set YearSet
 }
 
 // NewCopyright creates a new Copyright with the given holder name and year range.
 // The startYear must be within the last 50 years and not in the future.
 // If endYear is 0, only startYear is set. Otherwise, endYear must be after startYear and not in the past.
+// Use ValidationPolicy.NewCopyright to validate against another clock or limits.
 func NewCopyright(name string, startYear int, endYear int) (Copyright, error) {
-	currentYear := time.Now().Year()
-	fiftyYearsAgo := currentYear - MAX_YEARS_PAST
+	return DefaultValidationPolicy().NewCopyright(name, startYear, endYear)
+}
 
-	if startYear > currentYear {
-		return Copyright{}, StartYearTooNewError
+// Validate checks if the Copyright has a valid year range.
+// Returns an error if EndYear is set and is before StartYear, or if its
+// list of years is out of order or overlaps.
+func (c *Copyright) Validate() error {
+	if c.set != nil {
+		return c.set.Validate()
 	}
 
-	if startYear < fiftyYearsAgo {
-		return Copyright{}, StartYearTooOldError
+	if c.EndYear == 0 {
+		return nil
 	}
 
-	strippedName := strings.TrimSpace(name)
-	if len(strippedName) == 0 {
-		return Copyright{}, EmptyNameError
+	if c.EndYear < c.StartYear {
+		return EndYearBeforeStartError
 	}
 
-	if len(name) > MAX_NAME_LENGTH {
-		return Copyright{}, NameTooLongError
+	return nil
+}
+
+// Years returns the years the copyright covers.
+func (c Copyright) Years() YearSet {
+	if c.set != nil {
+		return c.set
 	}
 
-	if endYear != 0 {
-		if endYear < startYear {
-			return Copyright{}, EndYearBeforeStartError
-		}
+	if c.EndYear == 0 {
+		return YearSet{{Start: c.StartYear}}
+	}
 
-		if endYear < currentYear {
-			return Copyright{}, EndYearTooOldError
-		}
+	return YearSet{{Start: c.StartYear, End: c.EndYear}}
+}
 
-		return Copyright{Holder: name, StartYear: startYear, EndYear: endYear}, nil
+// SetYears updates the years the copyright covers, e.g. to "2018, 2020-2022, 2024".
+// StartYear and EndYear are set to the first and last year, EndYear is 0 when the years run to the present.
+func (c *Copyright) SetYears(years YearSet) error {
+	if err := years.Validate(); err != nil {
+		return err
 	}
 
-	return Copyright{Holder: name, StartYear: startYear}, nil
-}
+	first, last := years[0], years[len(years)-1]
 
-// Validate checks if the Copyright has a valid year range.
-// Returns an error if EndYear is set and is before StartYear.
-func (c *Copyright) Validate() error {
-	if c.EndYear == 0 {
-		return nil
+	c.StartYear = first.Start
+	c.EndYear = last.End
+	c.set = nil
+
+	if len(years) > 1 && !last.Present {
+		c.EndYear = last.last()
 	}
 
-	if c.EndYear < c.StartYear {
-		return EndYearBeforeStartError
+	if len(years) > 1 || last.Present {
+		c.set = slices.Clone(years)
 	}
 
 	return nil
 }
 
-// SetHolder updates the copyright holder name.
-// The holder must be non-empty and less than 128 characters.
-func (c *Copyright) SetHolder(holder string) error {
-	if len(holder) == 0 {
-		return EmptyHolderError
+// Equal reports whether both copyrights credit the same holder and cover the same years,
+// treating a range that starts and ends on the same year as that year.
+func (c Copyright) Equal(other Copyright) bool {
+	return c.Holder == other.Holder &&
+		c.Email == other.Email &&
+		c.Contributors == other.Contributors &&
+		slices.EqualFunc(c.Years(), other.Years(), YearRange.equal)
+}
+
+// Credit returns who the copyright credits as written in a copyright line, e.g. "Max Moon <max@example.com>"
+// or "Max Moon and contributors". License and header templates render it after the years.
+func (c Copyright) Credit() string {
+	credit := c.Holder
+	if c.Email != "" {
+		credit += " <" + c.Email + ">"
 	}
 
-	if len(holder) > 128 {
-		return HolderTooLongError
+	if c.Contributors {
+		credit += " and contributors"
 	}
 
-	c.Holder = holder
+	return credit
+}
 
-	return nil
+// SetHolder updates the copyright holder name.
+// The holder must be non-empty and less than 128 characters, the limit of DefaultValidationPolicy.
+// Use ValidationPolicy.SetHolder to validate against another policy.
+func (c *Copyright) SetHolder(holder string) error {
+	return DefaultValidationPolicy().SetHolder(c, holder)
 }
 
 // SetStartYear updates the start year of the copyright.
 // The year must be non-zero and not after EndYear if EndYear is set.
+// A copyright covering a list of years becomes a single range.
 func (c *Copyright) SetStartYear(year int) error {
 	if year == 0 {
 		return StartYearTooOldError
@@ -128,24 +219,99 @@ func (c *Copyright) SetStartYear(year int) error {
 	}
 
 	c.StartYear = year
+	c.set = nil
 
 	return nil
 }
 
 // SetEndYear updates the end year of the copyright.
 // The year must be after or equal to StartYear.
+// A copyright covering a list of years becomes a single range.
 func (c *Copyright) SetEndYear(year int) error {
 	if year < c.StartYear {
 		return EndYearBeforeStartError
 	}
 
 	c.EndYear = year
+	c.set = nil
 
 	return nil
 }
 
+// BumpYear extends the copyright to cover the given year. A range that starts and ends
+// on the same year is collapsed into that year, e.g. "2025-2025" becomes "2025".
+// For a list of years the last range is extended, ranges that run to the present are left alone.
+// Returns whether the copyright changed.
+func (c *Copyright) BumpYear(year int) (bool, error) {
+	if year < c.StartYear {
+		return false, EndYearBeforeStartError
+	}
+
+	before := *c
+
+	years := slices.Clone(c.Years())
+	last := &years[len(years)-1]
+
+	if !last.Present {
+		last.End = max(year, last.End)
+		if last.End <= last.Start {
+			last.End = 0
+		}
+	}
+
+	if err := c.SetYears(years); err != nil {
+		return false, err
+	}
+
+	changed := c.StartYear != before.StartYear || c.EndYear != before.EndYear || !slices.Equal(c.set, before.set)
+
+	return changed, nil
+}
+
+// Copyrights lists the copyright statements of a project, one per holder.
+// The first one is the primary copyright, the one a single holder and year range refer to.
+type Copyrights []Copyright
+
+// Holder returns the holder of the primary copyright.
+func (c Copyrights) Holder() string {
+	if len(c) == 0 {
+		return ""
+	}
+
+	return c[0].Holder
+}
+
+// StartYear returns the start year of the primary copyright.
+func (c Copyrights) StartYear() int {
+	if len(c) == 0 {
+		return 0
+	}
+
+	return c[0].StartYear
+}
+
+// EndYear returns the end year of the primary copyright.
+func (c Copyrights) EndYear() int {
+	if len(c) == 0 {
+		return 0
+	}
+
+	return c[0].EndYear
+}
+
+// index returns the position of the holder's copyright, or -1 when the holder has none.
+func (c Copyrights) index(holder string) int {
+	for idx, copyright := range c {
+		if copyright.Holder == holder {
+			return idx
+		}
+	}
+
+	return -1
+}
+
 // MITGenerator generates license files for the MIT license.
-func MITGenerator(projectName *string, cr *Copyright, dest *bytes.Buffer) ([]Writeable, error) {
+func MITGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
 	if err := MITTemplate.Execute(dest, cr); err != nil {
 		return nil, err
 	}
@@ -158,7 +324,7 @@ func MITGenerator(projectName *string, cr *Copyright, dest *bytes.Buffer) ([]Wri
 }
 
 // BoostGenerator generates license files for the Boost Software License 1.0.
-func BoostGenerator(projectName *string, cr *Copyright, dest *bytes.Buffer) ([]Writeable, error) {
+func BoostGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
 	writeableSlice := make([]Writeable, 1)
 	writeableSlice[0] = Writeable{Content: BoostBody, Path: "LICENSE"}
 	dest.Reset()
@@ -167,7 +333,7 @@ func BoostGenerator(projectName *string, cr *Copyright, dest *bytes.Buffer) ([]W
 }
 
 // UnlicenseGenerator generates license files for the Unlicense.
-func UnlicenseGenerator(projectName *string, cr *Copyright, dest *bytes.Buffer) ([]Writeable, error) {
+func UnlicenseGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
 	writeableSlice := make([]Writeable, 1)
 	writeableSlice[0] = Writeable{Content: UnlicenseBody, Path: "UNLICENSE"}
 	dest.Reset()
@@ -176,7 +342,7 @@ func UnlicenseGenerator(projectName *string, cr *Copyright, dest *bytes.Buffer)
 }
 
 // ApacheGenerator generates license files for the Apache License 2.0.
-func ApacheGenerator(projectName *string, cr *Copyright, dest *bytes.Buffer) ([]Writeable, error) {
+func ApacheGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
 	if err := ApacheTemplate.Execute(dest, cr); err != nil {
 		return nil, err
 	}
@@ -186,7 +352,7 @@ func ApacheGenerator(projectName *string, cr *Copyright, dest *bytes.Buffer) ([]
 
 	// Reset the buffer so we can re-use it
 	dest.Reset()
-	if err := SimpleNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, StartYear: cr.StartYear, EndYear: cr.EndYear, Holder: cr.Holder}); err != nil {
+	if err := SimpleNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, Copyrights: *cr}); err != nil {
 		return nil, err
 	}
 	writeableSlice[1] = Writeable{Content: dest.String(), Path: "NOTICE"}
@@ -196,13 +362,13 @@ func ApacheGenerator(projectName *string, cr *Copyright, dest *bytes.Buffer) ([]
 }
 
 // MozillaGenerator generates license files for the Mozilla Public License 2.0.
-func MozillaGenerator(projectName *string, cr *Copyright, dest *bytes.Buffer) ([]Writeable, error) {
+func MozillaGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
 	writeableSlice := make([]Writeable, 2)
 	writeableSlice[0] = Writeable{Content: MozillaLicenseBody, Path: "LICENSE"}
 
 	// Reset the buffer so we can re-use it
 	dest.Reset()
-	if err := SimpleNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, StartYear: cr.StartYear, EndYear: cr.EndYear, Holder: cr.Holder}); err != nil {
+	if err := SimpleNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, Copyrights: *cr}); err != nil {
 		return nil, err
 	}
 	writeableSlice[1] = Writeable{Content: dest.String(), Path: "NOTICE"}
@@ -211,13 +377,379 @@ func MozillaGenerator(projectName *string, cr *Copyright, dest *bytes.Buffer) ([
 	return writeableSlice, nil
 }
 
-// GNULesserGenerator generates license files for the GNU Lesser General Public License 3.0.
-func GNULesserGenerator(projectName *string, cr *Copyright, dest *bytes.Buffer) ([]Writeable, error) {
+// BSDZeroClauseGenerator generates license files for the BSD Zero Clause License.
+func BSDZeroClauseGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	if err := BSDZeroClauseTemplate.Execute(dest, cr); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: dest.String(), Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// BSD2ClauseGenerator generates license files for the BSD 2-Clause License.
+func BSD2ClauseGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	if err := BSD2ClauseTemplate.Execute(dest, cr); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: dest.String(), Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// BSD3ClauseGenerator generates license files for the BSD 3-Clause License.
+func BSD3ClauseGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	if err := BSD3ClauseTemplate.Execute(dest, cr); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: dest.String(), Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// BSD4ClauseGenerator generates license files for the original BSD 4-Clause License.
+func BSD4ClauseGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	if err := BSD4ClauseTemplate.Execute(dest, cr); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: dest.String(), Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// ISCGenerator generates license files for the ISC License.
+func ISCGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	if err := ISCTemplate.Execute(dest, cr); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: dest.String(), Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// ZlibGenerator generates license files for the zlib License.
+func ZlibGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	if err := ZlibTemplate.Execute(dest, cr); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: dest.String(), Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// PostgreSQLGenerator generates license files for the PostgreSQL License.
+func PostgreSQLGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	if err := PostgreSQLTemplate.Execute(dest, cr); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: dest.String(), Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// UnicodeGenerator generates license files for the Unicode License v3.
+func UnicodeGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	if err := UnicodeTemplate.Execute(dest, cr); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: dest.String(), Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// BlueOakGenerator generates license files for the Blue Oak Model License 1.0.0.
+func BlueOakGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: BlueOakBody, Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// CC0Generator generates license files for the Creative Commons CC0 1.0 Universal dedication.
+func CC0Generator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: CC0Body, Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// CCByGenerator generates license files for the Creative Commons Attribution 4.0 International license.
+func CCByGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	if err := CCByTemplate.Execute(dest, cr); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: dest.String(), Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// CCBySAGenerator generates license files for the Creative Commons Attribution-ShareAlike 4.0 International license.
+func CCBySAGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	if err := CCBySATemplate.Execute(dest, cr); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: dest.String(), Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// EclipseGenerator generates license files for the Eclipse Public License 2.0.
+// The NOTICE declares the Secondary License when params has one.
+func EclipseGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	writeableSlice := make([]Writeable, 2)
+	writeableSlice[0] = Writeable{Content: EclipseLicenseBody, Path: "LICENSE"}
+
+	dest.Reset()
+	if err := EclipseNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, Copyrights: *cr, SecondaryLicense: params.SecondaryLicense}); err != nil {
+		return nil, err
+	}
+	writeableSlice[1] = Writeable{Content: dest.String(), Path: "NOTICE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// BusinessSourceGenerator generates license files for the Business Source License 1.1.
+// The change date and change license have no sensible default so they must be set in params.
+func BusinessSourceGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	if params.ChangeDate.IsZero() {
+		return nil, MissingChangeDateError
+	}
+
+	if params.ChangeLicense == "" {
+		return nil, MissingChangeLicenseError
+	}
+
+	input := BusinessSourceInput{
+		Copyrights:         *cr,
+		Licensor:           params.Licensor,
+		LicensedWork:       params.LicensedWork,
+		AdditionalUseGrant: params.AdditionalUseGrant,
+		ChangeDate:         params.ChangeDate,
+		ChangeLicense:      params.ChangeLicense,
+	}
+
+	if input.Licensor == "" {
+		input.Licensor = cr.Holder()
+	}
+
+	if input.LicensedWork == "" {
+		input.LicensedWork = *projectName
+	}
+
+	if input.AdditionalUseGrant == "" {
+		input.AdditionalUseGrant = "None"
+	}
+
+	dest.Reset()
+	if err := BusinessSourceTemplate.Execute(dest, &input); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 2)
+	writeableSlice[0] = Writeable{Content: dest.String(), Path: "LICENSE"}
+
+	// Reset the buffer so we can re-use it
+	dest.Reset()
+	if err := SimpleNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, Copyrights: *cr}); err != nil {
+		return nil, err
+	}
+	writeableSlice[1] = Writeable{Content: dest.String(), Path: "NOTICE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// ElasticGenerator generates license files for the Elastic License 2.0.
+func ElasticGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
 	writeableSlice := make([]Writeable, 2)
-	writeableSlice[0] = Writeable{Content: GNULesserLicenseBody, Path: "COPYING.LESSER"}
+	writeableSlice[0] = Writeable{Content: ElasticLicenseBody, Path: "LICENSE"}
 
 	dest.Reset()
-	if err := GnuLesserNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, StartYear: cr.StartYear, EndYear: cr.EndYear, Holder: cr.Holder}); err != nil {
+	if err := SimpleNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, Copyrights: *cr}); err != nil {
+		return nil, err
+	}
+	writeableSlice[1] = Writeable{Content: dest.String(), Path: "NOTICE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// PolyFormNoncommercialGenerator generates license files for the PolyForm Noncommercial License 1.0.0.
+func PolyFormNoncommercialGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	if err := PolyFormNoncommercialTemplate.Execute(dest, cr); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: dest.String(), Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// ProprietaryGenerator generates license files for a proprietary license that reserves all rights.
+func ProprietaryGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	input := ProprietaryInput{
+		Copyrights:            *cr,
+		ConfidentialityClause: params.ConfidentialityClause,
+		ContactEmail:          params.ContactEmail,
+	}
+
+	if err := ProprietaryTemplate.Execute(dest, &input); err != nil {
+		return nil, err
+	}
+
+	writeableSlice := make([]Writeable, 1)
+	writeableSlice[0] = Writeable{Content: dest.String(), Path: "LICENSE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// GNULesserGenerator generates license files for the GNU Lesser General Public License 3.0 or later.
+// The LGPL is a set of additional permissions on top of the GPL, so the GPL text is written
+// to COPYING alongside the LGPL text in COPYING.LESSER.
+func GNULesserGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	return gnuLesserWriteables(GnuLesserNoticeTemplate, projectName, cr, dest)
+}
+
+// GNULesserOnlyGenerator generates license files for the GNU Lesser General Public License 3.0 only.
+// The GPL text is written to COPYING alongside the LGPL text in COPYING.LESSER, see GNULesserGenerator.
+func GNULesserOnlyGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	return gnuLesserWriteables(GnuLesserOnlyNoticeTemplate, projectName, cr, dest)
+}
+
+// gnuLesserWriteables renders the GPL and LGPL texts along with the given notice
+func gnuLesserWriteables(notice *template.Template, projectName *string, cr *Copyrights, dest *bytes.Buffer) ([]Writeable, error) {
+	writeableSlice := make([]Writeable, 3)
+	writeableSlice[0] = Writeable{Content: GNUGeneral3LicenseBody, Path: "COPYING"}
+	writeableSlice[1] = Writeable{Content: GNULesserLicenseBody, Path: "COPYING.LESSER"}
+
+	dest.Reset()
+	if err := notice.Execute(dest, &NoticeInput{ProjectName: *projectName, Copyrights: *cr}); err != nil {
+		return nil, err
+	}
+	writeableSlice[2] = Writeable{Content: dest.String(), Path: "NOTICE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// GNUGeneral2OnlyGenerator generates license files for the GNU General Public License 2.0 only.
+func GNUGeneral2OnlyGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	writeableSlice := make([]Writeable, 2)
+	writeableSlice[0] = Writeable{Content: GNUGeneral2LicenseBody, Path: "COPYING"}
+
+	dest.Reset()
+	if err := GnuGeneral2OnlyNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, Copyrights: *cr}); err != nil {
+		return nil, err
+	}
+	writeableSlice[1] = Writeable{Content: dest.String(), Path: "NOTICE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// GNUGeneral2OrLaterGenerator generates license files for the GNU General Public License 2.0 or later.
+func GNUGeneral2OrLaterGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	writeableSlice := make([]Writeable, 2)
+	writeableSlice[0] = Writeable{Content: GNUGeneral2LicenseBody, Path: "COPYING"}
+
+	dest.Reset()
+	if err := GnuGeneral2OrLaterNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, Copyrights: *cr}); err != nil {
+		return nil, err
+	}
+	writeableSlice[1] = Writeable{Content: dest.String(), Path: "NOTICE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// GNUGeneral3OnlyGenerator generates license files for the GNU General Public License 3.0 only.
+func GNUGeneral3OnlyGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	writeableSlice := make([]Writeable, 2)
+	writeableSlice[0] = Writeable{Content: GNUGeneral3LicenseBody, Path: "COPYING"}
+
+	dest.Reset()
+	if err := GnuGeneral3OnlyNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, Copyrights: *cr}); err != nil {
+		return nil, err
+	}
+	writeableSlice[1] = Writeable{Content: dest.String(), Path: "NOTICE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// GNUGeneral3OrLaterGenerator generates license files for the GNU General Public License 3.0 or later.
+func GNUGeneral3OrLaterGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	writeableSlice := make([]Writeable, 2)
+	writeableSlice[0] = Writeable{Content: GNUGeneral3LicenseBody, Path: "COPYING"}
+
+	dest.Reset()
+	if err := GnuGeneral3OrLaterNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, Copyrights: *cr}); err != nil {
+		return nil, err
+	}
+	writeableSlice[1] = Writeable{Content: dest.String(), Path: "NOTICE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// GNUAfferoOnlyGenerator generates license files for the GNU Affero General Public License 3.0 only.
+func GNUAfferoOnlyGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	writeableSlice := make([]Writeable, 2)
+	writeableSlice[0] = Writeable{Content: GNUAfferoLicenseBody, Path: "COPYING"}
+
+	dest.Reset()
+	if err := GnuAfferoOnlyNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, Copyrights: *cr}); err != nil {
+		return nil, err
+	}
+	writeableSlice[1] = Writeable{Content: dest.String(), Path: "NOTICE"}
+	dest.Reset()
+
+	return writeableSlice, nil
+}
+
+// GNUAfferoOrLaterGenerator generates license files for the GNU Affero General Public License 3.0 or later.
+func GNUAfferoOrLaterGenerator(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error) {
+	writeableSlice := make([]Writeable, 2)
+	writeableSlice[0] = Writeable{Content: GNUAfferoLicenseBody, Path: "COPYING"}
+
+	dest.Reset()
+	if err := GnuAfferoOrLaterNoticeTemplate.Execute(dest, &NoticeInput{ProjectName: *projectName, Copyrights: *cr}); err != nil {
 		return nil, err
 	}
 	writeableSlice[1] = Writeable{Content: dest.String(), Path: "NOTICE"}
@@ -235,9 +767,38 @@ const (
 	UNLICENSE
 	APACHE_2_0
 	MOZILLA_2_0
-	GNU_LESSER_3_0
+	GNU_LESSER_3_0_OR_LATER
+	BSD_0_CLAUSE
+	BSD_2_CLAUSE
+	BSD_3_CLAUSE
+	BSD_4_CLAUSE
+	GNU_GENERAL_2_0_ONLY
+	GNU_GENERAL_2_0_OR_LATER
+	GNU_GENERAL_3_0_ONLY
+	GNU_GENERAL_3_0_OR_LATER
+	GNU_AFFERO_3_0_ONLY
+	GNU_AFFERO_3_0_OR_LATER
+	ISC
+	ZLIB
+	POSTGRESQL
+	BLUE_OAK_1_0_0
+	UNICODE_3_0
+	CC0_1_0
+	CC_BY_4_0
+	CC_BY_SA_4_0
+	ECLIPSE_2_0
+	BUSINESS_SOURCE_1_1
+	ELASTIC_2_0
+	POLYFORM_NONCOMMERCIAL_1_0_0
+	PROPRIETARY
+	GNU_LESSER_3_0_ONLY
 )
 
+// GNU_LESSER_3_0 is the "or later" variant of the GNU Lesser General Public License 3.0.
+//
+// Deprecated: use GNU_LESSER_3_0_OR_LATER, or GNU_LESSER_3_0_ONLY.
+const GNU_LESSER_3_0 = GNU_LESSER_3_0_OR_LATER
+
 // AllLicensesTypes returns a slice of all supported license types.
 func AllLicensesTypes() []LicenseType {
 	return []LicenseType{
@@ -246,7 +807,31 @@ func AllLicensesTypes() []LicenseType {
 		UNLICENSE,
 		APACHE_2_0,
 		MOZILLA_2_0,
-		GNU_LESSER_3_0,
+		GNU_LESSER_3_0_ONLY,
+		GNU_LESSER_3_0_OR_LATER,
+		BSD_0_CLAUSE,
+		BSD_2_CLAUSE,
+		BSD_3_CLAUSE,
+		BSD_4_CLAUSE,
+		GNU_GENERAL_2_0_ONLY,
+		GNU_GENERAL_2_0_OR_LATER,
+		GNU_GENERAL_3_0_ONLY,
+		GNU_GENERAL_3_0_OR_LATER,
+		GNU_AFFERO_3_0_ONLY,
+		GNU_AFFERO_3_0_OR_LATER,
+		ISC,
+		ZLIB,
+		POSTGRESQL,
+		BLUE_OAK_1_0_0,
+		UNICODE_3_0,
+		CC0_1_0,
+		CC_BY_4_0,
+		CC_BY_SA_4_0,
+		ECLIPSE_2_0,
+		BUSINESS_SOURCE_1_1,
+		ELASTIC_2_0,
+		POLYFORM_NONCOMMERCIAL_1_0_0,
+		PROPRIETARY,
 	}
 }
 
@@ -257,7 +842,7 @@ type Writeable struct {
 }
 
 // WriteableGenerator is a function that generates license files for a given license type.
-type WriteableGenerator func(projectName *string, cr *Copyright, dest *bytes.Buffer) ([]Writeable, error)
+type WriteableGenerator func(projectName *string, cr *Copyrights, params *Parameters, dest *bytes.Buffer) ([]Writeable, error)
 
 // Template returns the license text template for this license type.
 func (lt LicenseType) Template() (string, error) {
@@ -272,8 +857,48 @@ func (lt LicenseType) Template() (string, error) {
 		return ApacheTemplateBody, nil
 	case MOZILLA_2_0:
 		return MozillaLicenseBody, nil
-	case GNU_LESSER_3_0:
+	case GNU_LESSER_3_0_ONLY, GNU_LESSER_3_0_OR_LATER:
 		return GNULesserLicenseBody, nil
+	case BSD_0_CLAUSE:
+		return BsdZeroClauseTemplateBody, nil
+	case BSD_2_CLAUSE:
+		return Bsd2ClauseTemplateBody, nil
+	case BSD_3_CLAUSE:
+		return Bsd3ClauseTemplateBody, nil
+	case BSD_4_CLAUSE:
+		return Bsd4ClauseTemplateBody, nil
+	case GNU_GENERAL_2_0_ONLY, GNU_GENERAL_2_0_OR_LATER:
+		return GNUGeneral2LicenseBody, nil
+	case GNU_GENERAL_3_0_ONLY, GNU_GENERAL_3_0_OR_LATER:
+		return GNUGeneral3LicenseBody, nil
+	case GNU_AFFERO_3_0_ONLY, GNU_AFFERO_3_0_OR_LATER:
+		return GNUAfferoLicenseBody, nil
+	case ISC:
+		return IscTemplateBody, nil
+	case ZLIB:
+		return ZlibTemplateBody, nil
+	case POSTGRESQL:
+		return PostgresqlTemplateBody, nil
+	case BLUE_OAK_1_0_0:
+		return BlueOakBody, nil
+	case UNICODE_3_0:
+		return UnicodeTemplateBody, nil
+	case CC0_1_0:
+		return CC0Body, nil
+	case CC_BY_4_0:
+		return CcByTemplateBody, nil
+	case CC_BY_SA_4_0:
+		return CcBySaTemplateBody, nil
+	case ECLIPSE_2_0:
+		return EclipseLicenseBody, nil
+	case BUSINESS_SOURCE_1_1:
+		return BusinessSourceTemplateBody, nil
+	case ELASTIC_2_0:
+		return ElasticLicenseBody, nil
+	case POLYFORM_NONCOMMERCIAL_1_0_0:
+		return PolyFormNoncommercialTemplateBody, nil
+	case PROPRIETARY:
+		return ProprietaryTemplateBody, nil
 	default:
 		return "", NoKnownTemplateError
 	}
@@ -292,14 +917,63 @@ func (lt LicenseType) String() string {
 		return "APACHE_2_0"
 	case MOZILLA_2_0:
 		return "MOZILLA_2_0"
-	case GNU_LESSER_3_0:
-		return "GNU_LESSER_3_0"
+	case GNU_LESSER_3_0_ONLY:
+		return "GNU_LESSER_3_0_ONLY"
+	case GNU_LESSER_3_0_OR_LATER:
+		return "GNU_LESSER_3_0_OR_LATER"
+	case BSD_0_CLAUSE:
+		return "BSD_0_CLAUSE"
+	case BSD_2_CLAUSE:
+		return "BSD_2_CLAUSE"
+	case BSD_3_CLAUSE:
+		return "BSD_3_CLAUSE"
+	case BSD_4_CLAUSE:
+		return "BSD_4_CLAUSE"
+	case GNU_GENERAL_2_0_ONLY:
+		return "GNU_GENERAL_2_0_ONLY"
+	case GNU_GENERAL_2_0_OR_LATER:
+		return "GNU_GENERAL_2_0_OR_LATER"
+	case GNU_GENERAL_3_0_ONLY:
+		return "GNU_GENERAL_3_0_ONLY"
+	case GNU_GENERAL_3_0_OR_LATER:
+		return "GNU_GENERAL_3_0_OR_LATER"
+	case GNU_AFFERO_3_0_ONLY:
+		return "GNU_AFFERO_3_0_ONLY"
+	case GNU_AFFERO_3_0_OR_LATER:
+		return "GNU_AFFERO_3_0_OR_LATER"
+	case ISC:
+		return "ISC"
+	case ZLIB:
+		return "ZLIB"
+	case POSTGRESQL:
+		return "POSTGRESQL"
+	case BLUE_OAK_1_0_0:
+		return "BLUE_OAK_1_0_0"
+	case UNICODE_3_0:
+		return "UNICODE_3_0"
+	case CC0_1_0:
+		return "CC0_1_0"
+	case CC_BY_4_0:
+		return "CC_BY_4_0"
+	case CC_BY_SA_4_0:
+		return "CC_BY_SA_4_0"
+	case ECLIPSE_2_0:
+		return "ECLIPSE_2_0"
+	case BUSINESS_SOURCE_1_1:
+		return "BUSINESS_SOURCE_1_1"
+	case ELASTIC_2_0:
+		return "ELASTIC_2_0"
+	case POLYFORM_NONCOMMERCIAL_1_0_0:
+		return "POLYFORM_NONCOMMERCIAL_1_0_0"
+	case PROPRIETARY:
+		return "PROPRIETARY"
 	default:
 		return "UNKNOWN"
 	}
 }
 
 // LicenseTypeFromString parses a license type from its string representation.
+// SPDX identifiers are accepted as well, see LicenseTypeFromSPDXID.
 // The input is case-insensitive.
 func LicenseTypeFromString(licenseType string) (LicenseType, error) {
 	licenseType = strings.ToUpper(licenseType)
@@ -307,30 +981,183 @@ func LicenseTypeFromString(licenseType string) (LicenseType, error) {
 	switch licenseType {
 	case "MIT":
 		return MIT, nil
-	case "BOOST":
+	case "BOOST_1_0", "BOOST":
 		return BOOST_1_0, nil
 	case "UNLICENSE":
 		return UNLICENSE, nil
-	case "APACHE":
+	case "APACHE_2_0", "APACHE":
 		return APACHE_2_0, nil
-	case "MOZILLA":
+	case "MOZILLA_2_0", "MOZILLA":
 		return MOZILLA_2_0, nil
-	case "GNU_LESSER":
-		return GNU_LESSER_3_0, nil
+	case "GNU_LESSER_3_0_ONLY":
+		return GNU_LESSER_3_0_ONLY, nil
+	case "GNU_LESSER_3_0_OR_LATER", "GNU_LESSER_3_0", "GNU_LESSER":
+		return GNU_LESSER_3_0_OR_LATER, nil
+	case "BSD_0_CLAUSE", "0BSD":
+		return BSD_0_CLAUSE, nil
+	case "BSD_2_CLAUSE":
+		return BSD_2_CLAUSE, nil
+	case "BSD_3_CLAUSE":
+		return BSD_3_CLAUSE, nil
+	case "BSD_4_CLAUSE":
+		return BSD_4_CLAUSE, nil
+	case "GNU_GENERAL_2_0_ONLY":
+		return GNU_GENERAL_2_0_ONLY, nil
+	case "GNU_GENERAL_2_0_OR_LATER":
+		return GNU_GENERAL_2_0_OR_LATER, nil
+	case "GNU_GENERAL_3_0_ONLY":
+		return GNU_GENERAL_3_0_ONLY, nil
+	case "GNU_GENERAL_3_0_OR_LATER":
+		return GNU_GENERAL_3_0_OR_LATER, nil
+	case "GNU_AFFERO_3_0_ONLY":
+		return GNU_AFFERO_3_0_ONLY, nil
+	case "GNU_AFFERO_3_0_OR_LATER":
+		return GNU_AFFERO_3_0_OR_LATER, nil
+	case "ISC":
+		return ISC, nil
+	case "ZLIB":
+		return ZLIB, nil
+	case "POSTGRESQL":
+		return POSTGRESQL, nil
+	case "BLUE_OAK_1_0_0", "BLUE_OAK":
+		return BLUE_OAK_1_0_0, nil
+	case "UNICODE_3_0", "UNICODE":
+		return UNICODE_3_0, nil
+	case "CC0_1_0", "CC0":
+		return CC0_1_0, nil
+	case "CC_BY_4_0", "CC_BY":
+		return CC_BY_4_0, nil
+	case "CC_BY_SA_4_0", "CC_BY_SA":
+		return CC_BY_SA_4_0, nil
+	case "ECLIPSE_2_0", "ECLIPSE":
+		return ECLIPSE_2_0, nil
+	case "BUSINESS_SOURCE_1_1", "BUSINESS_SOURCE":
+		return BUSINESS_SOURCE_1_1, nil
+	case "ELASTIC_2_0", "ELASTIC":
+		return ELASTIC_2_0, nil
+	case "POLYFORM_NONCOMMERCIAL_1_0_0", "POLYFORM_NONCOMMERCIAL":
+		return POLYFORM_NONCOMMERCIAL_1_0_0, nil
+	case "PROPRIETARY":
+		return PROPRIETARY, nil
+	default:
+		return LicenseTypeFromSPDXID(licenseType)
+	}
+}
+
+// SPDXID returns the SPDX license identifier for the license type.
+// Licenses without an SPDX list entry use a LicenseRef- identifier.
+func (lt LicenseType) SPDXID() string {
+	switch lt {
+	case MIT:
+		return "MIT"
+	case BOOST_1_0:
+		return "BSL-1.0"
+	case UNLICENSE:
+		return "Unlicense"
+	case APACHE_2_0:
+		return "Apache-2.0"
+	case MOZILLA_2_0:
+		return "MPL-2.0"
+	case GNU_LESSER_3_0_ONLY:
+		return "LGPL-3.0-only"
+	case GNU_LESSER_3_0_OR_LATER:
+		return "LGPL-3.0-or-later"
+	case BSD_0_CLAUSE:
+		return "0BSD"
+	case BSD_2_CLAUSE:
+		return "BSD-2-Clause"
+	case BSD_3_CLAUSE:
+		return "BSD-3-Clause"
+	case BSD_4_CLAUSE:
+		return "BSD-4-Clause"
+	case GNU_GENERAL_2_0_ONLY:
+		return "GPL-2.0-only"
+	case GNU_GENERAL_2_0_OR_LATER:
+		return "GPL-2.0-or-later"
+	case GNU_GENERAL_3_0_ONLY:
+		return "GPL-3.0-only"
+	case GNU_GENERAL_3_0_OR_LATER:
+		return "GPL-3.0-or-later"
+	case GNU_AFFERO_3_0_ONLY:
+		return "AGPL-3.0-only"
+	case GNU_AFFERO_3_0_OR_LATER:
+		return "AGPL-3.0-or-later"
+	case ISC:
+		return "ISC"
+	case ZLIB:
+		return "Zlib"
+	case POSTGRESQL:
+		return "PostgreSQL"
+	case BLUE_OAK_1_0_0:
+		return "BlueOak-1.0.0"
+	case UNICODE_3_0:
+		return "Unicode-3.0"
+	case CC0_1_0:
+		return "CC0-1.0"
+	case CC_BY_4_0:
+		return "CC-BY-4.0"
+	case CC_BY_SA_4_0:
+		return "CC-BY-SA-4.0"
+	case ECLIPSE_2_0:
+		return "EPL-2.0"
+	case BUSINESS_SOURCE_1_1:
+		return "BUSL-1.1"
+	case ELASTIC_2_0:
+		return "Elastic-2.0"
+	case POLYFORM_NONCOMMERCIAL_1_0_0:
+		return "PolyForm-Noncommercial-1.0.0"
+	case PROPRIETARY:
+		return "LicenseRef-Proprietary"
 	default:
-		return LicenseType(-1), InvalidLicenseType
+		return ""
+	}
+}
+
+// deprecatedSPDXIDs maps identifiers retired from the SPDX license list
+// to the license type they described
+var deprecatedSPDXIDs = map[string]LicenseType{
+	"GPL-2.0":   GNU_GENERAL_2_0_ONLY,
+	"GPL-2.0+":  GNU_GENERAL_2_0_OR_LATER,
+	"GPL-3.0":   GNU_GENERAL_3_0_ONLY,
+	"GPL-3.0+":  GNU_GENERAL_3_0_OR_LATER,
+	"AGPL-3.0":  GNU_AFFERO_3_0_ONLY,
+	"AGPL-3.0+": GNU_AFFERO_3_0_OR_LATER,
+	"LGPL-3.0":  GNU_LESSER_3_0_ONLY,
+	"LGPL-3.0+": GNU_LESSER_3_0_OR_LATER,
+}
+
+// LicenseTypeFromSPDXID parses a license type from an SPDX license identifier.
+// Current and deprecated identifiers are accepted, the input is case-insensitive.
+func LicenseTypeFromSPDXID(id string) (LicenseType, error) {
+	id = strings.TrimSpace(id)
+
+	for _, lt := range AllLicensesTypes() {
+		if strings.EqualFold(lt.SPDXID(), id) {
+			return lt, nil
+		}
+	}
+
+	for deprecated, lt := range deprecatedSPDXIDs {
+		if strings.EqualFold(deprecated, id) {
+			return lt, nil
+		}
 	}
+
+	return LicenseType(-1), InvalidLicenseType
 }
 
 // Compare compares the license template text with the provided text using the given comparison function.
+// Both sides are normalized with NormalizeLicenseText before comparing, so placeholders, copyright
+// lines, wrapping and other differences the SPDX matching guidelines ignore don't lower the score.
+// The template is normalized once and cached, see Matcher.
 // Returns the similarity score from the comparison function.
 func (lt LicenseType) Compare(left string, comparisonFunc func(left, right string) float64) (float64, error) {
-	tmp, err := lt.Template()
+	fp, err := lt.fingerprint()
 	if err != nil {
 		return 0.0, err
 	}
 
-	return comparisonFunc(left, tmp), nil
+	return comparisonFunc(NormalizeLicenseText(left), fp.normalized), nil
 }
 
 // GeneratorFunc returns the generator function for this license type.
@@ -346,8 +1173,56 @@ func (lt LicenseType) GeneratorFunc() (WriteableGenerator, error) {
 		return ApacheGenerator, nil
 	case MOZILLA_2_0:
 		return MozillaGenerator, nil
-	case GNU_LESSER_3_0:
+	case GNU_LESSER_3_0_ONLY:
+		return GNULesserOnlyGenerator, nil
+	case GNU_LESSER_3_0_OR_LATER:
 		return GNULesserGenerator, nil
+	case BSD_0_CLAUSE:
+		return BSDZeroClauseGenerator, nil
+	case BSD_2_CLAUSE:
+		return BSD2ClauseGenerator, nil
+	case BSD_3_CLAUSE:
+		return BSD3ClauseGenerator, nil
+	case BSD_4_CLAUSE:
+		return BSD4ClauseGenerator, nil
+	case GNU_GENERAL_2_0_ONLY:
+		return GNUGeneral2OnlyGenerator, nil
+	case GNU_GENERAL_2_0_OR_LATER:
+		return GNUGeneral2OrLaterGenerator, nil
+	case GNU_GENERAL_3_0_ONLY:
+		return GNUGeneral3OnlyGenerator, nil
+	case GNU_GENERAL_3_0_OR_LATER:
+		return GNUGeneral3OrLaterGenerator, nil
+	case GNU_AFFERO_3_0_ONLY:
+		return GNUAfferoOnlyGenerator, nil
+	case GNU_AFFERO_3_0_OR_LATER:
+		return GNUAfferoOrLaterGenerator, nil
+	case ISC:
+		return ISCGenerator, nil
+	case ZLIB:
+		return ZlibGenerator, nil
+	case POSTGRESQL:
+		return PostgreSQLGenerator, nil
+	case BLUE_OAK_1_0_0:
+		return BlueOakGenerator, nil
+	case UNICODE_3_0:
+		return UnicodeGenerator, nil
+	case CC0_1_0:
+		return CC0Generator, nil
+	case CC_BY_4_0:
+		return CCByGenerator, nil
+	case CC_BY_SA_4_0:
+		return CCBySAGenerator, nil
+	case ECLIPSE_2_0:
+		return EclipseGenerator, nil
+	case BUSINESS_SOURCE_1_1:
+		return BusinessSourceGenerator, nil
+	case ELASTIC_2_0:
+		return ElasticGenerator, nil
+	case POLYFORM_NONCOMMERCIAL_1_0_0:
+		return PolyFormNoncommercialGenerator, nil
+	case PROPRIETARY:
+		return ProprietaryGenerator, nil
 	default:
 		return nil, UnsupportedLicenseTypeError
 	}
@@ -356,17 +1231,48 @@ func (lt LicenseType) GeneratorFunc() (WriteableGenerator, error) {
 // RequiresNotice returns true if this license type requires a NOTICE file.
 func (lt LicenseType) RequiresNotice() bool {
 	switch lt {
-	case MOZILLA_2_0, GNU_LESSER_3_0, APACHE_2_0:
+	case MOZILLA_2_0, APACHE_2_0,
+		GNU_LESSER_3_0_ONLY, GNU_LESSER_3_0_OR_LATER,
+		GNU_GENERAL_2_0_ONLY, GNU_GENERAL_2_0_OR_LATER,
+		GNU_GENERAL_3_0_ONLY, GNU_GENERAL_3_0_OR_LATER,
+		GNU_AFFERO_3_0_ONLY, GNU_AFFERO_3_0_OR_LATER,
+		ECLIPSE_2_0, BUSINESS_SOURCE_1_1, ELASTIC_2_0:
 		return true
 	default:
 		return false
 	}
 }
 
+// incorporatedLicense returns the license whose text this license type builds on and has to be
+// distributed with, the GNU Lesser General Public License is a set of additional permissions on top of the GPL.
+func (lt LicenseType) incorporatedLicense() (LicenseType, bool) {
+	switch lt {
+	case GNU_LESSER_3_0_ONLY:
+		return GNU_GENERAL_3_0_ONLY, true
+	case GNU_LESSER_3_0_OR_LATER:
+		return GNU_GENERAL_3_0_OR_LATER, true
+	default:
+		return LicenseType(-1), false
+	}
+}
+
+// withIncorporatedLicenses returns the license types followed by the licenses they incorporate that aren't among them
+func withIncorporatedLicenses(licenseTypes []LicenseType) []LicenseType {
+	all := slices.Clone(licenseTypes)
+
+	for _, licenseType := range licenseTypes {
+		if incorporated, ok := licenseType.incorporatedLicense(); ok && !slices.Contains(all, incorporated) {
+			all = append(all, incorporated)
+		}
+	}
+
+	return all
+}
+
 // RequiresCopyright returns true if this license type requires copyright information.
 func (lt LicenseType) RequiresCopyright() bool {
 	switch lt {
-	case UNLICENSE, BOOST_1_0:
+	case UNLICENSE, BOOST_1_0, BLUE_OAK_1_0_0, CC0_1_0:
 		return false
 	default:
 		return true
@@ -376,46 +1282,53 @@ func (lt LicenseType) RequiresCopyright() bool {
 // License represents a complete license configuration with project name, copyright, and license type.
 type License struct {
 	projectName string
-	copyright   Copyright
+	copyrights  Copyrights
 	licenseType LicenseType
-}
-
-func validateProjectName(name string) error {
-	if len(name) == 0 {
-		return NameTooShortError
-	}
-
-	if len(name) > 128 {
-		return NameTooLongError
-	}
-
-	return nil
+	parameters  Parameters
+	// expression is set when the License covers more than a single license,
+	// licenseType then holds the first license in it
+	expression *Expression
+	// policy validates the Set* methods, DefaultValidationPolicy when nil
+	policy *ValidationPolicy
+	// unparsedCopyrights are the copyright-like lines Load couldn't parse
+	unparsedCopyrights []UnparsedCopyright
 }
 
 // New creates a new License with the given project name, copyright holder, year range, and license type.
 // The project name must be 1-128 characters after trimming whitespace.
+// Use ValidationPolicy.New to validate against another clock or limits.
 func New(projectName string, holder string, startYear int, endYear int, licenseType LicenseType) (*License, error) {
-	projectName = strings.TrimSpace(projectName)
+	return DefaultValidationPolicy().newLicense(projectName, holder, startYear, endYear, licenseType)
+}
 
-	if err := validateProjectName(projectName); err != nil {
+// NewFromExpression creates a new License covering every license in an SPDX license expression,
+// e.g. "MIT OR Apache-2.0".
+func NewFromExpression(projectName string, holder string, startYear int, endYear int, expression Expression) (*License, error) {
+	if err := expression.Validate(); err != nil {
 		return &License{}, err
 	}
 
-	copyright, err := NewCopyright(holder, startYear, endYear)
+	license, err := New(projectName, holder, startYear, endYear, expression.LicenseTypes()[0])
 	if err != nil {
 		return &License{}, err
 	}
 
-	return &License{
-		projectName: projectName,
-		copyright:   copyright,
-		licenseType: licenseType,
-	}, nil
+	if err := license.SetExpression(expression); err != nil {
+		return &License{}, err
+	}
+
+	return license, nil
 }
 
 // Render generates the license files for this License.
 // Returns a slice of Writeable containing the file content and paths where they should be written.
+// A License covering several licenses, or a license with an exception, is rendered as a LICENSE
+// with the combined statement, a LICENSE-<NAME> file per license and one merged NOTICE.
 func (l *License) Render() ([]Writeable, error) {
+	if l.expression != nil {
+		return l.renderCombined()
+	}
+
 	generatorFunc, err := l.licenseType.GeneratorFunc()
 	if err != nil {
 		return nil, err
@@ -423,7 +1336,7 @@ func (l *License) Render() ([]Writeable, error) {
 
 	var content bytes.Buffer
 
-	writeable, err := generatorFunc(&l.projectName, &l.copyright, &content)
+	writeable, err := generatorFunc(&l.projectName, &l.copyrights, &l.parameters, &content)
 
 	if err != nil {
 		return nil, err
@@ -432,15 +1345,82 @@ func (l *License) Render() ([]Writeable, error) {
 	return writeable, nil
 }
 
-// SetHolder updates the copyright holder name.
+// validationPolicy returns the policy the Set* methods validate with
+func (l *License) validationPolicy() ValidationPolicy {
+	if l.policy == nil {
+		return DefaultValidationPolicy()
+	}
+
+	return *l.policy
+}
+
+// SetValidationPolicy updates the policy the Set* methods validate with,
+// e.g. to keep a License loaded from a Repository to the same limits it was created with.
+func (l *License) SetValidationPolicy(policy ValidationPolicy) {
+	l.policy = &policy
+}
+
+// primaryCopyright returns the copyright the holder and year setters update
+func (l *License) primaryCopyright() *Copyright {
+	if len(l.copyrights) == 0 {
+		l.copyrights = Copyrights{{}}
+	}
+
+	return &l.copyrights[0]
+}
+
+// SetHolder updates the holder name of the primary copyright.
 func (l *License) SetHolder(holder string) error {
-	return l.copyright.SetHolder(holder)
+	if idx := l.copyrights.index(holder); idx > 0 {
+		return DuplicateHolderError
+	}
+
+	if err := l.validationPolicy().validateHolder(holder); err != nil {
+		return err
+	}
+
+	l.primaryCopyright().Holder = holder
+
+	return nil
+}
+
+// AddCopyright adds a copyright statement for another holder.
+// Each holder can only have one copyright.
+func (l *License) AddCopyright(copyright Copyright) error {
+	if l.copyrights.index(copyright.Holder) != -1 {
+		return DuplicateHolderError
+	}
+
+	if err := l.validationPolicy().validateCopyright(copyright); err != nil {
+		return err
+	}
+
+	l.copyrights = append(l.copyrights, copyright)
+
+	return nil
+}
+
+// RemoveHolder removes the copyright statement of a holder. The next holder becomes
+// the primary one when the primary holder is removed, the last holder can't be removed.
+func (l *License) RemoveHolder(holder string) error {
+	idx := l.copyrights.index(holder)
+	if idx == -1 {
+		return HolderNotFoundError
+	}
+
+	if len(l.copyrights) == 1 {
+		return LastHolderError
+	}
+
+	l.copyrights = slices.Delete(l.copyrights, idx, idx+1)
+
+	return nil
 }
 
 // SetProjectName updates the project name.
 // The name must be 1-128 characters.
 func (l *License) SetProjectName(name string) error {
-	if err := validateProjectName(name); err != nil {
+	if err := l.validationPolicy().validateProjectName(name); err != nil {
 		return err
 	}
 
@@ -449,18 +1429,117 @@ func (l *License) SetProjectName(name string) error {
 	return nil
 }
 
-// SetCopyrightEndYear updates the copyright end year.
+// SetCopyrightEndYear updates the end year of the primary copyright.
+// The year must not be in the future unless the validation policy allows it.
 func (l *License) SetCopyrightEndYear(year int) error {
-	return l.copyright.SetEndYear(year)
+	primary := l.primaryCopyright()
+
+	if err := l.validationPolicy().validateEndYear(primary.StartYear, year); err != nil {
+		return err
+	}
+
+	return primary.SetEndYear(year)
 }
 
-// SetCopyrightStartYear updates the copyright start year.
+// SetCopyrightStartYear updates the start year of the primary copyright.
+// The year must not be in the future, nor further in the past than the validation policy allows.
 func (l *License) SetCopyrightStartYear(year int) error {
-	return l.copyright.SetStartYear(year)
+	if err := l.validationPolicy().validateStartYear(year); err != nil {
+		return err
+	}
+
+	return l.primaryCopyright().SetStartYear(year)
+}
+
+// SetCopyrightYears updates the years of the primary copyright, e.g. to "2018, 2020-2022, 2024".
+// The first and last year are validated the same way as the start and end year.
+func (l *License) SetCopyrightYears(years YearSet) error {
+	if err := l.validationPolicy().validateYears(years); err != nil {
+		return err
+	}
+
+	return l.primaryCopyright().SetYears(years)
+}
+
+// SetSecondaryLicense updates the Secondary License declared in an Eclipse Public License 2.0 notice.
+// An empty name removes the declaration.
+func (l *License) SetSecondaryLicense(name string) error {
+	name = strings.TrimSpace(name)
+
+	if len(name) > MAX_SECONDARY_LICENSE_LENGTH {
+		return SecondaryLicenseTooLongError
+	}
+
+	l.parameters.SecondaryLicense = name
+
+	return nil
+}
+
+// SetParameters replaces the license specific parameters.
+func (l *License) SetParameters(parameters Parameters) error {
+	parameters.SecondaryLicense = strings.TrimSpace(parameters.SecondaryLicense)
+
+	if len(parameters.SecondaryLicense) > MAX_SECONDARY_LICENSE_LENGTH {
+		return SecondaryLicenseTooLongError
+	}
+
+	parameters.ContactEmail = strings.TrimSpace(parameters.ContactEmail)
+
+	if parameters.ContactEmail != "" {
+		// Only bare addresses, "Name <address>" doesn't read well in the license text
+		address, err := mail.ParseAddress(parameters.ContactEmail)
+		if err != nil || address.Address != parameters.ContactEmail {
+			return InvalidContactEmailError
+		}
+	}
+
+	l.parameters = parameters
+
+	return nil
+}
+
+// SetChangeDate updates the date a Business Source License converts to its change license.
+func (l *License) SetChangeDate(date time.Time) error {
+	if date.IsZero() {
+		return MissingChangeDateError
+	}
+
+	l.parameters.ChangeDate = date
+
+	return nil
 }
 
 // SetLicenseType updates the license type.
+// A License covering several licenses goes back to covering just this one.
 func (l *License) SetLicenseType(licenseType LicenseType) error {
 	l.licenseType = licenseType
+	l.expression = nil
+	return nil
+}
+
+// SetExpression updates the licenses covered by the License from an SPDX license expression.
+// The expression is normalized, its first license becomes the license type.
+func (l *License) SetExpression(expression Expression) error {
+	if err := expression.Validate(); err != nil {
+		return err
+	}
+
+	normalized := expression.Normalize()
+	l.licenseType = normalized.LicenseTypes()[0]
+	l.expression = nil
+
+	if normalized.requiresCombinedStatement() {
+		l.expression = &normalized
+	}
+
 	return nil
 }
+
+// licenseExpression returns the expression covered by the License, a single license unless set otherwise
+func (l *License) licenseExpression() Expression {
+	if l.expression != nil {
+		return *l.expression
+	}
+
+	return Expression{Operator: SIMPLE_EXPRESSION, LicenseType: l.licenseType}
+}
@@ -5,8 +5,19 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
+// testPolicy pins the current year, so tests with fixed years don't break every January
+var testPolicy = func() ValidationPolicy {
+	policy := DefaultValidationPolicy()
+	policy.Clock = func() time.Time {
+		return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	return policy
+}()
+
 func checkError(expected string, received error, t *testing.T) {
 	var errMsg string
 	if received != nil {
@@ -74,7 +85,7 @@ func TestCopyrightRender(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// When
-			rendered, err := NewCopyright(tc.input.holder, tc.input.year, 0)
+			rendered, err := testPolicy.NewCopyright(tc.input.holder, tc.input.year, 0)
 			checkError(tc.errorMessage, err, t)
 			if tc.errorMessage != "" {
 				return
@@ -86,13 +97,98 @@ func TestCopyrightRender(t *testing.T) {
 				Holder:    strings.TrimSpace(tc.input.holder),
 			}
 
-			if rendered != expected {
+			if !reflect.DeepEqual(expected, rendered) {
 				t.Errorf("Expected %v, got %v", expected, rendered)
 			}
 		})
 	}
 }
 
+func TestCopyrightBumpYear(t *testing.T) {
+	tests := []struct {
+		name            string
+		copyright       Copyright
+		year            int
+		expected        Copyright
+		expectedChanged bool
+		errorMessage    string
+	}{
+		{
+			name:            "Pass-Extend",
+			copyright:       Copyright{Holder: "Peanut Butter", StartYear: 2020, EndYear: 2024},
+			year:            2025,
+			expected:        Copyright{Holder: "Peanut Butter", StartYear: 2020, EndYear: 2025},
+			expectedChanged: true,
+		},
+		{
+			name:            "Pass-StartYearOnly",
+			copyright:       Copyright{Holder: "Peanut Butter", StartYear: 2020},
+			year:            2025,
+			expected:        Copyright{Holder: "Peanut Butter", StartYear: 2020, EndYear: 2025},
+			expectedChanged: true,
+		},
+		{
+			name:            "Pass-Collapse",
+			copyright:       Copyright{Holder: "Peanut Butter", StartYear: 2025, EndYear: 2025},
+			year:            2025,
+			expected:        Copyright{Holder: "Peanut Butter", StartYear: 2025},
+			expectedChanged: true,
+		},
+		{
+			name:            "Pass-Unchanged",
+			copyright:       Copyright{Holder: "Peanut Butter", StartYear: 2020, EndYear: 2025},
+			year:            2025,
+			expected:        Copyright{Holder: "Peanut Butter", StartYear: 2020, EndYear: 2025},
+			expectedChanged: false,
+		},
+		{
+			name:            "Pass-FutureEndYearKept",
+			copyright:       Copyright{Holder: "Peanut Butter", StartYear: 2020, EndYear: 2030},
+			year:            2025,
+			expected:        Copyright{Holder: "Peanut Butter", StartYear: 2020, EndYear: 2030},
+			expectedChanged: false,
+		},
+		{
+			name:            "Pass-ExtendList",
+			copyright:       Copyright{Holder: "Peanut Butter", StartYear: 2018, EndYear: 2022, set: YearSet{{Start: 2018}, {Start: 2020, End: 2022}}},
+			year:            2025,
+			expected:        Copyright{Holder: "Peanut Butter", StartYear: 2018, EndYear: 2025, set: YearSet{{Start: 2018}, {Start: 2020, End: 2025}}},
+			expectedChanged: true,
+		},
+		{
+			name:            "Pass-PresentUnchanged",
+			copyright:       Copyright{Holder: "Peanut Butter", StartYear: 2019, set: YearSet{{Start: 2019, Present: true}}},
+			year:            2025,
+			expected:        Copyright{Holder: "Peanut Butter", StartYear: 2019, set: YearSet{{Start: 2019, Present: true}}},
+			expectedChanged: false,
+		},
+		{
+			name:         "Fail-BeforeStart",
+			copyright:    Copyright{Holder: "Peanut Butter", StartYear: 2025},
+			year:         2024,
+			expected:     Copyright{Holder: "Peanut Butter", StartYear: 2025},
+			errorMessage: EndYearBeforeStartError.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			copyright := tc.copyright
+
+			changed, err := copyright.BumpYear(tc.year)
+			checkError(tc.errorMessage, err, t)
+
+			if changed != tc.expectedChanged {
+				t.Errorf("Expected changed %t, got %t", tc.expectedChanged, changed)
+			}
+
+			if !reflect.DeepEqual(tc.expected, copyright) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, copyright)
+			}
+		})
+	}
+}
+
 func TestLicenseRender(t *testing.T) {
 	type input struct {
 		startYear   int
@@ -100,6 +196,7 @@ func TestLicenseRender(t *testing.T) {
 		holder      string
 		projectName string
 		licenseType LicenseType
+		parameters  Parameters
 	}
 
 	tests := []struct {
@@ -120,7 +217,7 @@ func TestLicenseRender(t *testing.T) {
 			expectedBuilder: func(in input) ([]string, error) {
 				var expected bytes.Buffer
 
-				if err := MITTemplate.Execute(&expected, Copyright{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}); err != nil {
+				if err := MITTemplate.Execute(&expected, Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
 					return nil, nil
 				}
 
@@ -140,7 +237,7 @@ func TestLicenseRender(t *testing.T) {
 			expectedBuilder: func(in input) ([]string, error) {
 				var expected bytes.Buffer
 
-				if err := MITTemplate.Execute(&expected, Copyright{StartYear: in.startYear, EndYear: in.endYear, Holder: strings.TrimSpace(in.holder)}); err != nil {
+				if err := MITTemplate.Execute(&expected, Copyrights{{StartYear: in.startYear, EndYear: in.endYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
 					return nil, nil
 				}
 
@@ -186,14 +283,14 @@ func TestLicenseRender(t *testing.T) {
 				expected := make([]string, 2)
 
 				var dest bytes.Buffer
-				if err := ApacheTemplate.Execute(&dest, Copyright{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}); err != nil {
+				if err := ApacheTemplate.Execute(&dest, Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
 					return nil, nil
 				}
 
 				expected[0] = dest.String()
 
 				dest.Reset()
-				if err := SimpleNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}); err != nil {
+				if err := SimpleNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, Copyrights: Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}}); err != nil {
 					return nil, err
 				}
 
@@ -217,7 +314,7 @@ func TestLicenseRender(t *testing.T) {
 
 				// Reset the buffer so we can re-use it
 				var dest bytes.Buffer
-				if err := SimpleNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, StartYear: in.startYear, Holder: in.holder}); err != nil {
+				if err := SimpleNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, Copyrights: Copyrights{{StartYear: in.startYear, Holder: in.holder}}}); err != nil {
 					return nil, err
 				}
 				expected[1] = dest.String()
@@ -231,117 +328,995 @@ func TestLicenseRender(t *testing.T) {
 				holder:      "Peanut Butter",
 				projectName: "Cool",
 				startYear:   2025,
-				licenseType: GNU_LESSER_3_0,
+				licenseType: GNU_LESSER_3_0_OR_LATER,
 			},
 			errorMessage: "",
 			expectedBuilder: func(in input) ([]string, error) {
-				expected := make([]string, 2)
-				expected[0] = GNULesserLicenseBody
+				expected := make([]string, 3)
+				expected[0] = GNUGeneral3LicenseBody
+				expected[1] = GNULesserLicenseBody
 
 				// Reset the buffer so we can re-use it
 				var dest bytes.Buffer
-				if err := GnuLesserNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, StartYear: in.startYear, Holder: in.holder}); err != nil {
+				if err := GnuLesserNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, Copyrights: Copyrights{{StartYear: in.startYear, Holder: in.holder}}}); err != nil {
 					return nil, err
 				}
-				expected[1] = dest.String()
+				expected[2] = dest.String()
 
 				return expected, nil
 			},
 		},
-	}
+		{
+			name: "Pass-BSD-0-Clause",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: BSD_0_CLAUSE,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				var expected bytes.Buffer
 
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			license, err := New(tc.input.projectName, tc.input.holder, tc.input.startYear, tc.input.endYear, tc.input.licenseType)
-			if err != nil {
-				t.Errorf("Unexpected error %s", err.Error())
-				return
-			}
+				if err := BSDZeroClauseTemplate.Execute(&expected, Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
+					return nil, err
+				}
 
-			rendered, err := license.Render()
-			checkError(tc.errorMessage, err, t)
-			if tc.errorMessage != "" {
-				return
-			}
+				return []string{expected.String()}, nil
+			},
+		},
+		{
+			name: "Pass-BSD-2-Clause",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: BSD_2_CLAUSE,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				var expected bytes.Buffer
 
-			expected, err := tc.expectedBuilder(tc.input)
-			if err != nil {
-				t.Fatalf("Got error generating expected output %s", err.Error())
-				return
-			}
+				if err := BSD2ClauseTemplate.Execute(&expected, Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
+					return nil, err
+				}
 
-			renderedConent := make([]string, len(rendered))
-			for idx, render := range rendered {
-				renderedConent[idx] = render.Content
-			}
+				return []string{expected.String()}, nil
+			},
+		},
+		{
+			name: "Pass-BSD-3-Clause",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: BSD_3_CLAUSE,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				var expected bytes.Buffer
 
-			if !reflect.DeepEqual(expected, renderedConent) {
-				t.Errorf("Expected %s, got %s", expected, rendered)
-			}
-		})
-	}
-}
+				if err := BSD3ClauseTemplate.Execute(&expected, Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
+					return nil, err
+				}
 
-func TestLicenseTypeFromString(t *testing.T) {
-	tests := []struct {
-		name         string
-		input        string
-		expected     LicenseType
-		errorMessage string
-	}{
+				return []string{expected.String()}, nil
+			},
+		},
 		{
-			name:         "Passing-MIT",
-			input:        "mit",
-			expected:     MIT,
+			name: "Pass-BSD-4-Clause",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: BSD_4_CLAUSE,
+			},
 			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				var expected bytes.Buffer
+
+				if err := BSD4ClauseTemplate.Execute(&expected, Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
+					return nil, err
+				}
+
+				return []string{expected.String()}, nil
+			},
 		},
 		{
-			name:         "Passing-BOOST",
-			input:        "boost",
-			expected:     BOOST_1_0,
+			name: "Pass-GNU-GPL-2.0-Only",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: GNU_GENERAL_2_0_ONLY,
+			},
 			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				expected := make([]string, 2)
+				expected[0] = GNUGeneral2LicenseBody
+
+				var dest bytes.Buffer
+				if err := GnuGeneral2OnlyNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, Copyrights: Copyrights{{StartYear: in.startYear, Holder: in.holder}}}); err != nil {
+					return nil, err
+				}
+				expected[1] = dest.String()
+
+				return expected, nil
+			},
 		},
 		{
-			name:         "Passing-UNLICENSE",
-			input:        "unlicense",
-			expected:     UNLICENSE,
+			name: "Pass-GNU-GPL-2.0-Or-Later",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: GNU_GENERAL_2_0_OR_LATER,
+			},
 			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				expected := make([]string, 2)
+				expected[0] = GNUGeneral2LicenseBody
+
+				var dest bytes.Buffer
+				if err := GnuGeneral2OrLaterNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, Copyrights: Copyrights{{StartYear: in.startYear, Holder: in.holder}}}); err != nil {
+					return nil, err
+				}
+				expected[1] = dest.String()
+
+				return expected, nil
+			},
 		},
 		{
-			name:         "Passing-APACHE",
-			input:        "apache",
-			expected:     APACHE_2_0,
+			name: "Pass-GNU-GPL-3.0-Only",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: GNU_GENERAL_3_0_ONLY,
+			},
 			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				expected := make([]string, 2)
+				expected[0] = GNUGeneral3LicenseBody
+
+				var dest bytes.Buffer
+				if err := GnuGeneral3OnlyNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, Copyrights: Copyrights{{StartYear: in.startYear, Holder: in.holder}}}); err != nil {
+					return nil, err
+				}
+				expected[1] = dest.String()
+
+				return expected, nil
+			},
 		},
 		{
-			name:         "Passing-MOZILLA",
-			input:        "mozilla",
-			expected:     MOZILLA_2_0,
+			name: "Pass-GNU-GPL-3.0-Or-Later",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: GNU_GENERAL_3_0_OR_LATER,
+			},
 			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				expected := make([]string, 2)
+				expected[0] = GNUGeneral3LicenseBody
+
+				var dest bytes.Buffer
+				if err := GnuGeneral3OrLaterNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, Copyrights: Copyrights{{StartYear: in.startYear, Holder: in.holder}}}); err != nil {
+					return nil, err
+				}
+				expected[1] = dest.String()
+
+				return expected, nil
+			},
 		},
 		{
-			name:         "Passing-GNU_LESSER",
-			input:        "gnu_lesser",
-			expected:     GNU_LESSER_3_0,
+			name: "Pass-GNU-AGPL-3.0-Only",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: GNU_AFFERO_3_0_ONLY,
+			},
 			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				expected := make([]string, 2)
+				expected[0] = GNUAfferoLicenseBody
+
+				var dest bytes.Buffer
+				if err := GnuAfferoOnlyNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, Copyrights: Copyrights{{StartYear: in.startYear, Holder: in.holder}}}); err != nil {
+					return nil, err
+				}
+				expected[1] = dest.String()
+
+				return expected, nil
+			},
 		},
 		{
-			name:         "Failing-Invalid",
-			input:        "foobar",
-			expected:     LicenseType(-1),
-			errorMessage: InvalidLicenseType.Error(),
-		},
-	}
+			name: "Pass-GNU-AGPL-3.0-Or-Later",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: GNU_AFFERO_3_0_OR_LATER,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				expected := make([]string, 2)
+				expected[0] = GNUAfferoLicenseBody
 
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			lt, err := LicenseTypeFromString(tc.input)
+				var dest bytes.Buffer
+				if err := GnuAfferoOrLaterNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, Copyrights: Copyrights{{StartYear: in.startYear, Holder: in.holder}}}); err != nil {
+					return nil, err
+				}
+				expected[1] = dest.String()
 
-			checkError(tc.errorMessage, err, t)
+				return expected, nil
+			},
+		},
+		{
+			name: "Pass-ISC",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: ISC,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				var expected bytes.Buffer
 
-			if lt != tc.expected {
-				t.Errorf("Expected %s, got %s", tc.expected.String(), lt.String())
+				if err := ISCTemplate.Execute(&expected, Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
+					return nil, err
+				}
+
+				return []string{expected.String()}, nil
+			},
+		},
+		{
+			name: "Pass-Zlib",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: ZLIB,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				var expected bytes.Buffer
+
+				if err := ZlibTemplate.Execute(&expected, Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
+					return nil, err
+				}
+
+				return []string{expected.String()}, nil
+			},
+		},
+		{
+			name: "Pass-PostgreSQL",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: POSTGRESQL,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				var expected bytes.Buffer
+
+				if err := PostgreSQLTemplate.Execute(&expected, Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
+					return nil, err
+				}
+
+				return []string{expected.String()}, nil
+			},
+		},
+		{
+			name: "Pass-Unicode-3.0",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: UNICODE_3_0,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				var expected bytes.Buffer
+
+				if err := UnicodeTemplate.Execute(&expected, Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
+					return nil, err
+				}
+
+				return []string{expected.String()}, nil
+			},
+		},
+		{
+			name: "Pass-CC-BY-4.0",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: CC_BY_4_0,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				var expected bytes.Buffer
+
+				if err := CCByTemplate.Execute(&expected, Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
+					return nil, err
+				}
+
+				return []string{expected.String()}, nil
+			},
+		},
+		{
+			name: "Pass-CC-BY-SA-4.0",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: CC_BY_SA_4_0,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				var expected bytes.Buffer
+
+				if err := CCBySATemplate.Execute(&expected, Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
+					return nil, err
+				}
+
+				return []string{expected.String()}, nil
+			},
+		},
+		{
+			name: "Pass-CC0-1.0",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: CC0_1_0,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				return []string{CC0Body}, nil
+			},
+		},
+		{
+			name: "Pass-Eclipse-2.0",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: ECLIPSE_2_0,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				expected := make([]string, 2)
+				expected[0] = EclipseLicenseBody
+
+				var dest bytes.Buffer
+				if err := EclipseNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, Copyrights: Copyrights{{StartYear: in.startYear, Holder: in.holder}}}); err != nil {
+					return nil, err
+				}
+				expected[1] = dest.String()
+
+				return expected, nil
+			},
+		},
+		{
+			name: "Pass-Business-Source-1.1",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: BUSINESS_SOURCE_1_1,
+				parameters: Parameters{
+					Licensor:           "Peanut Butter Inc.",
+					LicensedWork:       "Cool 1.0",
+					AdditionalUseGrant: "You may make production use of the Licensed Work for non-commercial purposes.",
+					ChangeDate:         time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC),
+					ChangeLicense:      "Apache License, Version 2.0",
+				},
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				expected := make([]string, 2)
+
+				var dest bytes.Buffer
+				if err := BusinessSourceTemplate.Execute(&dest, &BusinessSourceInput{
+					Copyrights:         Copyrights{{StartYear: in.startYear, Holder: in.holder}},
+					Licensor:           in.parameters.Licensor,
+					LicensedWork:       in.parameters.LicensedWork,
+					AdditionalUseGrant: in.parameters.AdditionalUseGrant,
+					ChangeDate:         in.parameters.ChangeDate,
+					ChangeLicense:      in.parameters.ChangeLicense,
+				}); err != nil {
+					return nil, err
+				}
+				expected[0] = dest.String()
+
+				dest.Reset()
+				if err := SimpleNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, Copyrights: Copyrights{{StartYear: in.startYear, Holder: in.holder}}}); err != nil {
+					return nil, err
+				}
+				expected[1] = dest.String()
+
+				return expected, nil
+			},
+		},
+		{
+			name: "Pass-Business-Source-1.1-Defaults",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: BUSINESS_SOURCE_1_1,
+				parameters: Parameters{
+					ChangeDate:    time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC),
+					ChangeLicense: "Apache License, Version 2.0",
+				},
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				expected := make([]string, 2)
+
+				var dest bytes.Buffer
+				if err := BusinessSourceTemplate.Execute(&dest, &BusinessSourceInput{
+					Copyrights:         Copyrights{{StartYear: in.startYear, Holder: in.holder}},
+					Licensor:           in.holder,
+					LicensedWork:       in.projectName,
+					AdditionalUseGrant: "None",
+					ChangeDate:         in.parameters.ChangeDate,
+					ChangeLicense:      in.parameters.ChangeLicense,
+				}); err != nil {
+					return nil, err
+				}
+				expected[0] = dest.String()
+
+				dest.Reset()
+				if err := SimpleNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, Copyrights: Copyrights{{StartYear: in.startYear, Holder: in.holder}}}); err != nil {
+					return nil, err
+				}
+				expected[1] = dest.String()
+
+				return expected, nil
+			},
+		},
+		{
+			name: "Fail-Business-Source-1.1-NoChangeDate",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: BUSINESS_SOURCE_1_1,
+				parameters: Parameters{
+					ChangeLicense: "Apache License, Version 2.0",
+				},
+			},
+			errorMessage: MissingChangeDateError.Error(),
+		},
+		{
+			name: "Fail-Business-Source-1.1-NoChangeLicense",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: BUSINESS_SOURCE_1_1,
+				parameters: Parameters{
+					ChangeDate: time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			errorMessage: MissingChangeLicenseError.Error(),
+		},
+		{
+			name: "Pass-Elastic-2.0",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: ELASTIC_2_0,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				expected := make([]string, 2)
+				expected[0] = ElasticLicenseBody
+
+				var dest bytes.Buffer
+				if err := SimpleNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: in.projectName, Copyrights: Copyrights{{StartYear: in.startYear, Holder: in.holder}}}); err != nil {
+					return nil, err
+				}
+				expected[1] = dest.String()
+
+				return expected, nil
+			},
+		},
+		{
+			name: "Pass-PolyForm-Noncommercial-1.0.0",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: POLYFORM_NONCOMMERCIAL_1_0_0,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				var expected bytes.Buffer
+
+				if err := PolyFormNoncommercialTemplate.Execute(&expected, Copyrights{{StartYear: in.startYear, Holder: strings.TrimSpace(in.holder)}}); err != nil {
+					return nil, err
+				}
+
+				return []string{expected.String()}, nil
+			},
+		},
+		{
+			name: "Pass-Proprietary",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: PROPRIETARY,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				var expected bytes.Buffer
+
+				if err := ProprietaryTemplate.Execute(&expected, &ProprietaryInput{Copyrights: Copyrights{{StartYear: in.startYear, Holder: in.holder}}}); err != nil {
+					return nil, err
+				}
+
+				return []string{expected.String()}, nil
+			},
+		},
+		{
+			name: "Pass-Proprietary-ClauseAndEmail",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: PROPRIETARY,
+				parameters: Parameters{
+					ConfidentialityClause: DefaultConfidentialityClause,
+					ContactEmail:          "legal@example.com",
+				},
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				var expected bytes.Buffer
+
+				if err := ProprietaryTemplate.Execute(&expected, &ProprietaryInput{
+					Copyrights:            Copyrights{{StartYear: in.startYear, Holder: in.holder}},
+					ConfidentialityClause: in.parameters.ConfidentialityClause,
+					ContactEmail:          in.parameters.ContactEmail,
+				}); err != nil {
+					return nil, err
+				}
+
+				return []string{expected.String()}, nil
+			},
+		},
+		{
+			name: "Pass-BlueOak-1.0.0",
+			input: input{
+				holder:      "Peanut Butter",
+				projectName: "Cool",
+				startYear:   2025,
+				licenseType: BLUE_OAK_1_0_0,
+			},
+			errorMessage: "",
+			expectedBuilder: func(in input) ([]string, error) {
+				return []string{BlueOakBody}, nil
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			license, err := testPolicy.New(tc.input.projectName, tc.input.holder, tc.input.startYear, tc.input.endYear, tc.input.licenseType)
+			if err != nil {
+				t.Errorf("Unexpected error %s", err.Error())
+				return
+			}
+
+			if err = license.SetParameters(tc.input.parameters); err != nil {
+				t.Errorf("Unexpected error %s", err.Error())
+				return
+			}
+
+			rendered, err := license.Render()
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			expected, err := tc.expectedBuilder(tc.input)
+			if err != nil {
+				t.Fatalf("Got error generating expected output %s", err.Error())
+				return
+			}
+
+			renderedConent := make([]string, len(rendered))
+			for idx, render := range rendered {
+				renderedConent[idx] = render.Content
+			}
+
+			if !reflect.DeepEqual(expected, renderedConent) {
+				t.Errorf("Expected %s, got %s", expected, rendered)
 			}
 		})
 	}
 }
+
+func TestLicenseTypeFromString(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expected     LicenseType
+		errorMessage string
+	}{
+		{
+			name:         "Passing-MIT",
+			input:        "mit",
+			expected:     MIT,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-BOOST",
+			input:        "boost",
+			expected:     BOOST_1_0,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-UNLICENSE",
+			input:        "unlicense",
+			expected:     UNLICENSE,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-APACHE",
+			input:        "apache",
+			expected:     APACHE_2_0,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-MOZILLA",
+			input:        "mozilla",
+			expected:     MOZILLA_2_0,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-GNU_LESSER",
+			input:        "gnu_lesser",
+			expected:     GNU_LESSER_3_0_OR_LATER,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-BSD_0_CLAUSE",
+			input:        "0bsd",
+			expected:     BSD_0_CLAUSE,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-BSD_2_CLAUSE",
+			input:        "bsd_2_clause",
+			expected:     BSD_2_CLAUSE,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-BSD_3_CLAUSE",
+			input:        "bsd_3_clause",
+			expected:     BSD_3_CLAUSE,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-BSD_4_CLAUSE",
+			input:        "bsd_4_clause",
+			expected:     BSD_4_CLAUSE,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-GNU_GENERAL_2_0_ONLY",
+			input:        "gnu_general_2_0_only",
+			expected:     GNU_GENERAL_2_0_ONLY,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-GNU_GENERAL_2_0_OR_LATER",
+			input:        "gnu_general_2_0_or_later",
+			expected:     GNU_GENERAL_2_0_OR_LATER,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-GNU_GENERAL_3_0_ONLY",
+			input:        "gnu_general_3_0_only",
+			expected:     GNU_GENERAL_3_0_ONLY,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-GNU_GENERAL_3_0_OR_LATER",
+			input:        "gnu_general_3_0_or_later",
+			expected:     GNU_GENERAL_3_0_OR_LATER,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-GNU_AFFERO_3_0_ONLY",
+			input:        "gnu_affero_3_0_only",
+			expected:     GNU_AFFERO_3_0_ONLY,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-GNU_AFFERO_3_0_OR_LATER",
+			input:        "gnu_affero_3_0_or_later",
+			expected:     GNU_AFFERO_3_0_OR_LATER,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-ISC",
+			input:        "isc",
+			expected:     ISC,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-ZLIB",
+			input:        "zlib",
+			expected:     ZLIB,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-POSTGRESQL",
+			input:        "postgresql",
+			expected:     POSTGRESQL,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-BLUE_OAK_1_0_0",
+			input:        "blue_oak_1_0_0",
+			expected:     BLUE_OAK_1_0_0,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-UNICODE_3_0",
+			input:        "unicode_3_0",
+			expected:     UNICODE_3_0,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-CC0_1_0",
+			input:        "cc0",
+			expected:     CC0_1_0,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-CC_BY_4_0",
+			input:        "cc_by_4_0",
+			expected:     CC_BY_4_0,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-CC_BY_SA_4_0",
+			input:        "cc_by_sa",
+			expected:     CC_BY_SA_4_0,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-ECLIPSE_2_0",
+			input:        "eclipse",
+			expected:     ECLIPSE_2_0,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-BUSINESS_SOURCE_1_1",
+			input:        "business_source_1_1",
+			expected:     BUSINESS_SOURCE_1_1,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-ELASTIC_2_0",
+			input:        "elastic",
+			expected:     ELASTIC_2_0,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-POLYFORM_NONCOMMERCIAL_1_0_0",
+			input:        "polyform_noncommercial",
+			expected:     POLYFORM_NONCOMMERCIAL_1_0_0,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-PROPRIETARY",
+			input:        "proprietary",
+			expected:     PROPRIETARY,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-SPDXID",
+			input:        "Apache-2.0",
+			expected:     APACHE_2_0,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-DeprecatedSPDXID",
+			input:        "GPL-3.0+",
+			expected:     GNU_GENERAL_3_0_OR_LATER,
+			errorMessage: "",
+		},
+		{
+			name:         "Failing-Invalid",
+			input:        "foobar",
+			expected:     LicenseType(-1),
+			errorMessage: InvalidLicenseType.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lt, err := LicenseTypeFromString(tc.input)
+
+			checkError(tc.errorMessage, err, t)
+
+			if lt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected.String(), lt.String())
+			}
+		})
+	}
+}
+
+func TestLicenseTypeFromSPDXID(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expected     LicenseType
+		errorMessage string
+	}{
+		{
+			name:         "Passing-MixedCase",
+			input:        "bsd-3-clause",
+			expected:     BSD_3_CLAUSE,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-LicenseRef",
+			input:        "LicenseRef-Proprietary",
+			expected:     PROPRIETARY,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-Deprecated-GPL-2.0",
+			input:        "GPL-2.0",
+			expected:     GNU_GENERAL_2_0_ONLY,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-Deprecated-AGPL-3.0",
+			input:        "AGPL-3.0",
+			expected:     GNU_AFFERO_3_0_ONLY,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-Deprecated-LGPL-3.0-Plus",
+			input:        "LGPL-3.0+",
+			expected:     GNU_LESSER_3_0_OR_LATER,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-LGPL-3.0-Only",
+			input:        "LGPL-3.0-only",
+			expected:     GNU_LESSER_3_0_ONLY,
+			errorMessage: "",
+		},
+		{
+			name:         "Passing-Deprecated-LGPL-3.0",
+			input:        "LGPL-3.0",
+			expected:     GNU_LESSER_3_0_ONLY,
+			errorMessage: "",
+		},
+		{
+			name:         "Failing-Unknown",
+			input:        "WTFPL",
+			expected:     LicenseType(-1),
+			errorMessage: InvalidLicenseType.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lt, err := LicenseTypeFromSPDXID(tc.input)
+
+			checkError(tc.errorMessage, err, t)
+
+			if lt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected.String(), lt.String())
+			}
+		})
+	}
+}
+
+func TestLicenseTypeRoundTrip(t *testing.T) {
+	for _, licenseType := range AllLicensesTypes() {
+		t.Run(licenseType.String(), func(t *testing.T) {
+			fromName, err := LicenseTypeFromString(licenseType.String())
+			if err != nil {
+				t.Fatalf("Parsing name %s: %v", licenseType.String(), err)
+			}
+
+			if fromName != licenseType {
+				t.Errorf("Expected %s from name, got %s", licenseType.String(), fromName.String())
+			}
+
+			fromSPDX, err := LicenseTypeFromString(licenseType.SPDXID())
+			if err != nil {
+				t.Fatalf("Parsing SPDX ID %s: %v", licenseType.SPDXID(), err)
+			}
+
+			if fromSPDX != licenseType {
+				t.Errorf("Expected %s from SPDX ID, got %s", licenseType.String(), fromSPDX.String())
+			}
+		})
+	}
+}
+
+func TestLGPLSPDXIDRoundTrip(t *testing.T) {
+	tests := []struct {
+		id        string
+		expected  LicenseType
+		canonical string
+	}{
+		{id: "LGPL-3.0-only", expected: GNU_LESSER_3_0_ONLY, canonical: "LGPL-3.0-only"},
+		{id: "LGPL-3.0", expected: GNU_LESSER_3_0_ONLY, canonical: "LGPL-3.0-only"},
+		{id: "LGPL-3.0-or-later", expected: GNU_LESSER_3_0_OR_LATER, canonical: "LGPL-3.0-or-later"},
+		{id: "LGPL-3.0+", expected: GNU_LESSER_3_0_OR_LATER, canonical: "LGPL-3.0-or-later"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.id, func(t *testing.T) {
+			licenseType, err := LicenseTypeFromSPDXID(tc.id)
+			if err != nil {
+				t.Fatalf("Parsing SPDX ID %s: %v", tc.id, err)
+			}
+
+			if licenseType != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected.String(), licenseType.String())
+			}
+
+			if licenseType.SPDXID() != tc.canonical {
+				t.Errorf("Expected SPDX ID %s, got %s", tc.canonical, licenseType.SPDXID())
+			}
+
+			expression, err := ParseExpression(tc.id)
+			if err != nil {
+				t.Fatalf("Parsing expression %s: %v", tc.id, err)
+			}
+
+			if expression.String() != tc.canonical {
+				t.Errorf("Expected expression %s, got %s", tc.canonical, expression.String())
+			}
+		})
+	}
+}
+
+func TestLicenseTypeValuesAreStable(t *testing.T) {
+	tests := []struct {
+		value    LicenseType
+		expected LicenseType
+	}{
+		{value: 1, expected: MIT},
+		{value: 2, expected: BOOST_1_0},
+		{value: 3, expected: UNLICENSE},
+		{value: 4, expected: APACHE_2_0},
+		{value: 5, expected: MOZILLA_2_0},
+		{value: 6, expected: GNU_LESSER_3_0},
+		{value: 6, expected: GNU_LESSER_3_0_OR_LATER},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expected.String(), func(t *testing.T) {
+			if tc.value != tc.expected {
+				t.Errorf("Expected %s to be %d, got %d", tc.expected.String(), tc.value, tc.expected)
+			}
+		})
+	}
+
+	if GNU_LESSER_3_0_ONLY <= PROPRIETARY {
+		t.Errorf("Expected GNU_LESSER_3_0_ONLY to come after PROPRIETARY, got %d", GNU_LESSER_3_0_ONLY)
+	}
+}
@@ -0,0 +1,236 @@
+package ligen
+
+import (
+	"slices"
+	"strings"
+)
+
+// EmbeddedLicense is a license text found in part of a document, e.g. one of the licenses
+// of the vendored dependencies concatenated into a single LICENSE file.
+type EmbeddedLicense struct {
+	LicenseType LicenseType
+	// Score is the similarity of the license text to the license, between 0.0 and 1.0
+	Score float64
+	// Start and End are the byte offsets of the license text in the document, End is exclusive
+	Start int
+	End   int
+	// Lines are the lines the license text spans
+	Lines Region
+	// Copyrights are the copyright lines in and directly above the license text,
+	// copyrights that are part of the license itself, e.g. the Free Software Foundation's, are left out
+	Copyrights Copyrights
+}
+
+const (
+	// MIN_ANCHOR_WORDS is the minimum amount of words a line needs to tie a part of a document
+	// to a license, shorter lines appear in too many licenses to tell them apart
+	MIN_ANCHOR_WORDS = 3
+	// MAX_SEGMENT_GAP is the maximum amount of lines not found in a license that can sit
+	// between two parts of its text, e.g. a clause added to it, before the parts are split up
+	MAX_SEGMENT_GAP = 5
+)
+
+// anchorLines normalizes every line of the content, leaving out lines too short to tie a part of the content to a license
+func anchorLines(content string) []string {
+	lines := normalizeLines(content)
+	for idx, line := range lines {
+		if len(strings.Fields(line)) < MIN_ANCHOR_WORDS {
+			lines[idx] = ""
+		}
+	}
+
+	return lines
+}
+
+// licenseSpans returns the regions of the content that follow the normalized license text from top
+// to bottom, line indexes are 0-based. A line found earlier in the license text than the one before it
+// starts a new region, so the same license included twice is reported twice.
+func licenseSpans(anchors []string, normalized string) []Region {
+	var spans []Region
+	open := false
+	cursor, gap := 0, 0
+
+	for idx, line := range anchors {
+		if line == "" {
+			continue
+		}
+
+		pos := -1
+		if open {
+			if found := strings.Index(normalized[cursor:], line); found != -1 {
+				pos = cursor + found
+			}
+		}
+
+		restart := false
+		if pos == -1 {
+			pos = strings.Index(normalized, line)
+			restart = pos != -1
+		}
+
+		if pos == -1 {
+			gap++
+			if gap > MAX_SEGMENT_GAP {
+				open = false
+			}
+
+			continue
+		}
+
+		if open && !restart {
+			spans[len(spans)-1].EndLine = idx
+		} else {
+			spans = append(spans, Region{StartLine: idx, EndLine: idx})
+			open = true
+		}
+
+		cursor, gap = pos+len(line), 0
+	}
+
+	return spans
+}
+
+// overlaps reports whether the regions share a line
+func (r Region) overlaps(other Region) bool {
+	return r.StartLine <= other.EndLine && other.StartLine <= r.EndLine
+}
+
+// LocateLicenses segments a document holding several license texts, e.g. a LICENSE file that
+// bundles the licenses of vendored dependencies, and reports every license found in it ordered
+// by where it starts. Parts of the document are tied to a license by the lines found in its text,
+// each part must reach the threshold similarity (0.0-1.0) on its own.
+// Returns DetectionFailedError when no license is found, or the error of NewMatcher when the
+// license texts can't be fingerprinted.
+// Use a Matcher when locating the licenses of many documents.
+func LocateLicenses(content string, threshold float64) ([]EmbeddedLicense, error) {
+	matcher, err := NewMatcher(threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	return matcher.LocateLicenses(content)
+}
+
+// LocateLicenses reports every license found in a document holding several license texts, see the package level LocateLicenses.
+func (m Matcher) LocateLicenses(content string) ([]EmbeddedLicense, error) {
+	lines := strings.Split(content, "\n")
+	normalizedLines := normalizeLines(content)
+	anchors := anchorLines(content)
+
+	var candidates []EmbeddedLicense
+	templates := make(map[LicenseType]string, len(m.fingerprints))
+
+	for _, fp := range m.fingerprints {
+		templates[fp.licenseType] = fp.normalized
+
+		for _, span := range licenseSpans(anchors, fp.normalized) {
+			text := strings.Join(lines[span.StartLine:span.EndLine+1], "\n")
+
+			score := newBigrams(NormalizeLicenseText(text)).similarity(fp.bigrams)
+			if score < m.threshold {
+				continue
+			}
+
+			candidates = append(candidates, EmbeddedLicense{LicenseType: fp.licenseType, Score: score, Lines: span})
+		}
+	}
+
+	// Licenses of the same family match the same lines, the most similar one wins
+	slices.SortFunc(candidates, func(a, b EmbeddedLicense) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+
+			return 1
+		}
+
+		return (b.Lines.EndLine - b.Lines.StartLine) - (a.Lines.EndLine - a.Lines.StartLine)
+	})
+
+	var located []EmbeddedLicense
+	for _, candidate := range candidates {
+		if !slices.ContainsFunc(located, func(l EmbeddedLicense) bool { return l.Lines.overlaps(candidate.Lines) }) {
+			located = append(located, candidate)
+		}
+	}
+
+	if len(located) == 0 {
+		return nil, DetectionFailedError
+	}
+
+	slices.SortFunc(located, func(a, b EmbeddedLicense) int {
+		return a.Lines.StartLine - b.Lines.StartLine
+	})
+
+	offsets := make([]int, len(lines)+1)
+	for idx, line := range lines {
+		offsets[idx+1] = offsets[idx] + len(line) + 1
+	}
+
+	previousEnd := -1
+	for idx := range located {
+		embedded := &located[idx]
+		normalized := templates[embedded.LicenseType]
+
+		nextStart := len(lines)
+		if idx+1 < len(located) {
+			nextStart = located[idx+1].Lines.StartLine
+		}
+
+		// Lines too short to be anchors, e.g. the title, belong to the license when found in its text,
+		// copyrights usually sit right above it
+		belongs := func(line int) bool {
+			return normalizedLines[line] == "" || strings.Contains(normalized, normalizedLines[line])
+		}
+
+		start := embedded.Lines.StartLine
+		for start-1 > previousEnd && belongs(start-1) {
+			start--
+		}
+
+		end := embedded.Lines.EndLine
+		for end+1 < nextStart && belongs(end+1) {
+			end++
+		}
+
+		for start < end && strings.TrimSpace(lines[start]) == "" {
+			start++
+		}
+
+		for end > start && strings.TrimSpace(lines[end]) == "" {
+			end--
+		}
+
+		previousEnd = end
+
+		text := strings.Join(lines[start:end+1], "\n")
+		embedded.LicenseType = resolveFamily(embedded.LicenseType, text)
+		embedded.Copyrights = embeddedCopyrights(text, embedded.LicenseType)
+
+		embedded.Lines = Region{StartLine: start + 1, EndLine: end + 1}
+		embedded.Start = offsets[start]
+		embedded.End = offsets[end] + len(lines[end])
+	}
+
+	return located, nil
+}
+
+// embeddedCopyrights returns the copyrights in the text, leaving out the ones that are part of the license text
+func embeddedCopyrights(text string, licenseType LicenseType) Copyrights {
+	tmp, _ := licenseType.Template()
+
+	var copyrights Copyrights
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if !looksLikeCopyright(line) || strings.Contains(tmp, line) {
+			continue
+		}
+
+		if copyright, err := ParseCopyright(line); err == nil {
+			copyrights = append(copyrights, copyright)
+		}
+	}
+
+	return copyrights
+}
@@ -0,0 +1,129 @@
+package ligen
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLocateLicenses(t *testing.T) {
+	var buf bytes.Buffer
+	mit, err := buildInput(MITGenerator, "Ligen", "Max Moon", 2025, 0, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf.Reset()
+	bsd, err := buildInput(BSD3ClauseGenerator, "Ligen", "Peanut Butter Inc.", 2020, 2025, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mit = strings.TrimSpace(mit)
+	bsd = strings.TrimSpace(bsd)
+
+	preamble := "This bundle includes third party software.\n\n"
+	separator := "\n\n----------------------------------------\n\nPortions of this software are licensed under the following terms:\n\n"
+	bundle := preamble + mit + separator + bsd + "\n"
+
+	mitLines := strings.Count(mit, "\n") + 1
+	bsdStart := strings.Count(preamble+mit+separator, "\n") + 1
+
+	tests := []struct {
+		name     string
+		content  string
+		expected []EmbeddedLicense
+		err      error
+	}{
+		{
+			name:    "Pass-Single",
+			content: mit,
+			expected: []EmbeddedLicense{
+				{
+					LicenseType: MIT,
+					Start:       0,
+					End:         len(mit),
+					Lines:       Region{StartLine: 1, EndLine: mitLines},
+					Copyrights:  Copyrights{{Holder: "Max Moon", StartYear: 2025}},
+				},
+			},
+		},
+		{
+			name:    "Pass-Bundle",
+			content: bundle,
+			expected: []EmbeddedLicense{
+				{
+					LicenseType: MIT,
+					Start:       len(preamble),
+					End:         len(preamble + mit),
+					Lines:       Region{StartLine: 3, EndLine: mitLines + 2},
+					Copyrights:  Copyrights{{Holder: "Max Moon", StartYear: 2025}},
+				},
+				{
+					LicenseType: BSD_3_CLAUSE,
+					Start:       len(preamble + mit + separator),
+					End:         len(preamble + mit + separator + bsd),
+					Lines:       Region{StartLine: bsdStart, EndLine: bsdStart + strings.Count(bsd, "\n")},
+					Copyrights:  Copyrights{{Holder: "Peanut Butter Inc.", StartYear: 2020, EndYear: 2025}},
+				},
+			},
+		},
+		{
+			name:    "Fail-NoLicense",
+			content: "This project has no license yet.\n\nPlease check back later.\n",
+			err:     DetectionFailedError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			located, err := LocateLicenses(tc.content, 0.90)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected error %v, got %v", tc.err, err)
+			}
+
+			for idx := range located {
+				if located[idx].Score < 0.90 {
+					t.Errorf("Expected a score of at least %f, got %f", 0.90, located[idx].Score)
+				}
+
+				located[idx].Score = 0
+			}
+
+			if !reflect.DeepEqual(located, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, located)
+			}
+
+			for _, embedded := range located {
+				if text := tc.content[embedded.Start:embedded.End]; !strings.HasPrefix(text, "MIT License") && !strings.HasPrefix(text, "BSD 3-Clause License") {
+					t.Errorf("Expected the span to start at the title, got %q", text[:min(len(text), 40)])
+				}
+			}
+		})
+	}
+}
+
+func TestLocateLicensesSkipsTemplateCopyrights(t *testing.T) {
+	var buf bytes.Buffer
+	gpl, err := buildInput(GNUGeneral3OnlyGenerator, "Ligen", "Max Moon", 2025, 0, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	located, err := LocateLicenses(gpl, 0.90)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(located) != 1 {
+		t.Fatalf("Expected a single license, got %+v", located)
+	}
+
+	for _, copyright := range located[0].Copyrights {
+		if strings.Contains(copyright.Holder, "Free Software Foundation") {
+			t.Errorf("Expected the license's own copyright to be left out, got %+v", located[0].Copyrights)
+		}
+	}
+}
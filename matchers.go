@@ -3,6 +3,7 @@ package ligen
 import (
 	"errors"
 	"slices"
+	"strings"
 )
 
 var (
@@ -39,16 +40,18 @@ func newBigrams(s string) biggrams {
 	return bg
 }
 
-// SorensonDiceCoefficient calculates the similarity between two strings using bigram comparison.
-// Returns a value between 0.0 (no similarity) and 1.0 (identical).
-func SorensonDiceCoefficient(left, right string) float64 {
-	bigramsleft := newBigrams(left)
-	bigramsright := newBigrams(right)
+// similarity is the Sørensen–Dice coefficient of the two sets of bigrams
+func (b biggrams) similarity(other biggrams) float64 {
+	// Walking the smaller set keeps the lookups down when a short text is compared against a long one
+	smaller, larger := b, other
+	if smaller.len() > larger.len() {
+		smaller, larger = larger, smaller
+	}
 
-	intersection := bigramsleft.intersection(bigramsright)
+	intersection := smaller.intersection(larger)
 
 	numerator := float64(2 * intersection)
-	denominator := float64(bigramsleft.len() + bigramsright.len())
+	denominator := float64(b.len() + other.len())
 
 	if denominator == 0 {
 		return 0.0
@@ -57,57 +60,421 @@ func SorensonDiceCoefficient(left, right string) float64 {
 	return numerator / denominator
 }
 
-type score struct {
+// SorensonDiceCoefficient calculates the similarity between two strings using bigram comparison.
+// Returns a value between 0.0 (no similarity) and 1.0 (identical).
+func SorensonDiceCoefficient(left, right string) float64 {
+	return newBigrams(left).similarity(newBigrams(right))
+}
+
+// familyMember is a license type within a licenseFamily along with the phrases
+// that only appear in its text.
+type familyMember struct {
+	licenseType LicenseType
+	markers     []string
+}
+
+// licenseFamily groups license types whose texts are close enough that the similarity
+// score alone can't reliably tell them apart. Members are checked in order and the first
+// one whose markers all appear in the content wins, so the member without markers goes last.
+type licenseFamily []familyMember
+
+func (f licenseFamily) contains(licenseType LicenseType) bool {
+	return slices.ContainsFunc(f, func(m familyMember) bool {
+		return m.licenseType == licenseType
+	})
+}
+
+func (f licenseFamily) resolve(content string) LicenseType {
+	for _, member := range f {
+		found := true
+
+		for _, marker := range member.markers {
+			if !strings.Contains(content, marker) {
+				found = false
+				break
+			}
+		}
+
+		if found {
+			return member.licenseType
+		}
+	}
+
+	return f[len(f)-1].licenseType
+}
+
+var licenseFamilies = []licenseFamily{
+	{
+		{licenseType: BSD_4_CLAUSE, markers: []string{"all advertising materials mentioning features"}},
+		{licenseType: BSD_3_CLAUSE, markers: []string{"neither the name of"}},
+		{licenseType: BSD_2_CLAUSE},
+	},
+	{
+		// The GNU Lesser layout ships the GPL text next to the LGPL text, so content
+		// that carries both is the LGPL even though it scores closest to the GPL
+		{licenseType: GNU_LESSER_3_0_ONLY, markers: []string{"this version of the gnu lesser general public license incorporates"}},
+		{licenseType: GNU_AFFERO_3_0_ONLY, markers: []string{"remote network interaction"}},
+		{licenseType: GNU_GENERAL_3_0_ONLY},
+	},
+	{
+		{licenseType: ISC, markers: []string{"provided that the above copyright notice and this permission notice appear in all copies"}},
+		{licenseType: BSD_0_CLAUSE},
+	},
+	{
+		{licenseType: CC_BY_SA_4_0, markers: []string{"sharealike"}},
+		{licenseType: CC_BY_4_0},
+	},
+}
+
+// collapseForMarkers lowercases content and collapses runs of whitespace so markers
+// still match text that has been re-wrapped or re-indented.
+func collapseForMarkers(content string) string {
+	return strings.Join(strings.Fields(strings.ToLower(content)), " ")
+}
+
+// resolveFamily settles which member of a license family the content belongs to.
+// License types that don't belong to a family are returned as-is.
+func resolveFamily(licenseType LicenseType, content string) LicenseType {
+	for _, family := range licenseFamilies {
+		if family.contains(licenseType) {
+			return family.resolve(collapseForMarkers(content))
+		}
+	}
+
+	return licenseType
+}
+
+// versionChoice pairs the "only" and "or later" variants of a license. Both variants
+// share the same license text, the choice between them is made in the project's notice.
+type versionChoice struct {
+	only    LicenseType
+	orLater LicenseType
+}
+
+var versionChoices = []versionChoice{
+	{only: GNU_GENERAL_2_0_ONLY, orLater: GNU_GENERAL_2_0_OR_LATER},
+	{only: GNU_GENERAL_3_0_ONLY, orLater: GNU_GENERAL_3_0_OR_LATER},
+	{only: GNU_AFFERO_3_0_ONLY, orLater: GNU_AFFERO_3_0_OR_LATER},
+	{only: GNU_LESSER_3_0_ONLY, orLater: GNU_LESSER_3_0_OR_LATER},
+}
+
+// onlyVariant returns the "only" variant of an "or later" license type, other license types are returned as-is
+func onlyVariant(licenseType LicenseType) LicenseType {
+	for _, choice := range versionChoices {
+		if licenseType == choice.orLater {
+			return choice.only
+		}
+	}
+
+	return licenseType
+}
+
+func isOrLaterVariant(licenseType LicenseType) bool {
+	return slices.ContainsFunc(versionChoices, func(c versionChoice) bool {
+		return c.orLater == licenseType
+	})
+}
+
+// resolveVersionChoice uses the notice to decide between the "only" and "or later"
+// variants of a license. License types without variants are returned as-is.
+func resolveVersionChoice(licenseType LicenseType, notice string) LicenseType {
+	for _, choice := range versionChoices {
+		if licenseType != choice.only && licenseType != choice.orLater {
+			continue
+		}
+
+		if strings.Contains(collapseForMarkers(notice), "any later version") {
+			return choice.orLater
+		}
+
+		return choice.only
+	}
+
+	return licenseType
+}
+
+// shortNotice lists the names a license goes by when a project points to it with a
+// short statement, e.g. "This work is licensed under a Creative Commons ...", instead
+// of shipping the full text.
+type shortNotice struct {
 	licenseType LicenseType
-	distance    float64
+	names       []string
+}
+
+var shortNotices = []shortNotice{
+	{licenseType: CC_BY_SA_4_0, names: []string{"creative commons attribution-sharealike 4.0 international", "cc by-sa 4.0"}},
+	{licenseType: CC_BY_4_0, names: []string{"creative commons attribution 4.0 international", "cc by 4.0"}},
+	{licenseType: CC0_1_0, names: []string{"cc0 1.0 universal", "cc0 1.0", "has waived all copyright and related or neighboring rights"}},
+	// The boilerplate these licenses ask to be put at the top of each source file
+	{licenseType: APACHE_2_0, names: []string{"licensed under the apache license, version 2.0"}},
+	{licenseType: MOZILLA_2_0, names: []string{"subject to the terms of the mozilla public license, v. 2.0"}},
+	// Organizations often swap in their own confidentiality clause, which can be long
+	// enough to throw off the comparison against the proprietary template
+	{licenseType: PROPRIETARY, names: []string{"proprietary property of the copyright holder", "proprietary and confidential"}},
+}
+
+// matchShortNotice looks for the name of a license in content that is too short
+// to be compared against the full license texts.
+func matchShortNotice(content string) (shortNotice, bool) {
+	collapsed := collapseForMarkers(content)
+
+	for _, notice := range shortNotices {
+		for _, name := range notice.names {
+			if strings.Contains(collapsed, name) {
+				return notice, true
+			}
+		}
+	}
+
+	return shortNotice{}, false
+}
+
+// DetectionMethod is how a candidate license was identified.
+type DetectionMethod int
+
+const (
+	// TEXT_SIMILARITY means the content was compared against the license text with SorensonDiceCoefficient
+	TEXT_SIMILARITY DetectionMethod = iota
+	// FAMILY_MARKERS means the content was closest to a license of the same family,
+	// and phrases only found in this license's text settled which member it is
+	FAMILY_MARKERS
+	// SHORT_NOTICE means the content is too short to be compared against the license
+	// texts, but names the license, e.g. "This work is licensed under a Creative Commons ..."
+	SHORT_NOTICE
+)
+
+// String returns the name of the detection method.
+func (m DetectionMethod) String() string {
+	switch m {
+	case TEXT_SIMILARITY:
+		return "TEXT_SIMILARITY"
+	case FAMILY_MARKERS:
+		return "FAMILY_MARKERS"
+	case SHORT_NOTICE:
+		return "SHORT_NOTICE"
+	default:
+		return ""
+	}
+}
+
+// Region is a range of lines, 1-based and inclusive.
+type Region struct {
+	StartLine int
+	EndLine   int
+}
+
+// Candidate is a license the content may be licensed under.
+type Candidate struct {
+	LicenseType LicenseType
+	// Score is how confident the detection is, between 0.0 and 1.0. It's the similarity
+	// of the content to the license text, or 1.0 when a short notice names the license.
+	Score  float64
+	Method DetectionMethod
+	// Regions are the lines of the content found in the license text, or naming it for short notices.
+	// Only set for the best candidate and the candidates that reach the threshold.
+	Regions []Region
+}
+
+// Detection lists the licenses the content was compared against, ranked by how well they match.
+type Detection struct {
+	// Candidates are sorted by score, the best match first
+	Candidates []Candidate
+	// Threshold is the minimum score the best candidate needs to be a match
+	Threshold float64
+}
+
+// Match returns the best candidate when it reaches the threshold.
+// Returns DetectionFailedError otherwise.
+func (d Detection) Match() (Candidate, error) {
+	if len(d.Candidates) == 0 || d.Candidates[0].Score < d.Threshold {
+		return Candidate{}, DetectionFailedError
+	}
+
+	return d.Candidates[0], nil
+}
+
+// byScore sorts candidates from the highest score to the lowest, candidates with the
+// same score keep the order of AllLicensesTypes
+func byScore(a, b Candidate) int {
+	if a.Score != b.Score {
+		if a.Score > b.Score {
+			return -1
+		}
+
+		return 1
+	}
+
+	return int(a.LicenseType) - int(b.LicenseType)
+}
+
+// withoutLicenseType drops the candidate for a license type, so it isn't listed twice
+// once it's been identified another way
+func withoutLicenseType(candidates []Candidate, licenseType LicenseType) []Candidate {
+	return slices.DeleteFunc(candidates, func(c Candidate) bool {
+		return c.LicenseType == licenseType
+	})
+}
+
+// normalizeLines normalizes every line of the content on its own, so lines can be matched against license texts
+func normalizeLines(content string) []string {
+	lines := strings.Split(content, "\n")
+	for idx, line := range lines {
+		lines[idx] = NormalizeLicenseText(line)
+	}
+
+	return lines
+}
+
+// matchedRegions returns the regions made of normalized lines that match.
+// Lines that are empty once normalized, e.g. blank or copyright lines, don't break a region.
+func matchedRegions(lines []string, matches func(line string) bool) []Region {
+	var regions []Region
+	open := false
+
+	for idx, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		if !matches(line) {
+			open = false
+			continue
+		}
+
+		if open {
+			regions[len(regions)-1].EndLine = idx + 1
+			continue
+		}
+
+		regions = append(regions, Region{StartLine: idx + 1, EndLine: idx + 1})
+		open = true
+	}
+
+	return regions
+}
+
+// textRegions returns the regions of the normalized lines found in the normalized license text
+func textRegions(lines []string, normalized string) []Region {
+	return matchedRegions(lines, func(line string) bool {
+		return strings.Contains(normalized, line)
+	})
+}
+
+// noticeRegions returns the regions of the normalized lines naming the license, all of them when the name is wrapped
+func noticeRegions(lines []string, names []string) []Region {
+	regions := matchedRegions(lines, func(line string) bool {
+		return slices.ContainsFunc(names, func(name string) bool {
+			return strings.Contains(line, name)
+		})
+	})
+
+	if len(regions) == 0 {
+		regions = []Region{{StartLine: 1, EndLine: len(lines)}}
+	}
+
+	return regions
+}
+
+// Detect compares the content against every known license and ranks them by how well they match.
+// Licenses that come in "only" and "or later" variants are listed as the "only" variant,
+// since the license text alone doesn't say which applies.
+// The threshold parameter specifies the minimum similarity score (0.0-1.0) the best candidate needs.
+// When the best candidate belongs to a family of licenses with near identical texts, the member
+// the content holds the phrases of ranks first, ahead of the license whose text it's closest to.
+// When no license reaches the threshold, a short notice naming a license ranks first instead.
+// Returns the error of NewMatcher if the license texts can't be fingerprinted.
+// Use a Matcher when detecting the licenses of many files.
+func Detect(content string, threshold float64) (Detection, error) {
+	matcher, err := NewMatcher(threshold)
+	if err != nil {
+		return Detection{}, err
+	}
+
+	return matcher.Detect(content)
 }
 
 // Match identifies the license type of the given content by comparing it against known license templates.
+// Licenses that come in "only" and "or later" variants are reported as the "only" variant,
+// since the license text alone doesn't say which applies.
 // The threshold parameter specifies the minimum similarity score (0.0-1.0) required for a successful match.
-// Returns an error if no license meets the threshold or if comparison fails.
+// Content that doesn't reach the threshold is checked for a short notice naming the license instead.
+// Returns DetectionFailedError if no license meets the threshold, or the error of NewMatcher
+// if the license texts can't be fingerprinted.
+// Use Detect to see every candidate and how close it came, and a Matcher when matching many files.
 func Match(content string, threshold float64) (LicenseType, error) {
-	knownLicenseTypes := AllLicensesTypes()
-	scores := make([]score, len(knownLicenseTypes))
+	matcher, err := NewMatcher(threshold)
+	if err != nil {
+		return LicenseType(-1), err
+	}
+
+	return matcher.Match(content)
+}
+
+// Detect compares the content against every known license and ranks them by how well they match,
+// see the package level Detect.
+func (m Matcher) Detect(content string) (Detection, error) {
+	if len(m.fingerprints) == 0 {
+		return Detection{}, DetectionFailedError
+	}
+
+	normalized := newBigrams(NormalizeLicenseText(content))
+	candidates := make([]Candidate, 0, len(m.fingerprints))
 
-	for idx, licenseType := range knownLicenseTypes {
-		distance, err := licenseType.Compare(content, SorensonDiceCoefficient)
+	for _, fp := range m.fingerprints {
+		candidates = append(candidates, Candidate{LicenseType: fp.licenseType, Score: normalized.similarity(fp.bigrams), Method: TEXT_SIMILARITY})
+	}
+
+	slices.SortFunc(candidates, byScore)
 
-		if err != nil {
-			return LicenseType(-1), DetectionFailedError
+	lines := normalizeLines(content)
+
+	for idx := range candidates {
+		if idx > 0 && candidates[idx].Score < m.threshold {
+			break
 		}
 
-		// If the coefficient is 1, it's an exect match
-		// so don't bother iterating through the rest
-		if distance == 1 {
-			return licenseType, nil
+		fp, ok := m.fingerprint(candidates[idx].LicenseType)
+		if !ok {
+			return Detection{}, DetectionFailedError
 		}
 
-		scores[idx] = score{licenseType: licenseType, distance: distance}
+		candidates[idx].Regions = textRegions(lines, fp.normalized)
 	}
 
-	slices.SortFunc(scores, func(a, b score) int {
-		adst := a.distance
-		bdst := b.distance
+	best := candidates[0]
 
-		if adst > bdst {
-			return 1
+	if best.Score >= m.threshold {
+		if resolved := resolveFamily(best.LicenseType, content); resolved != best.LicenseType {
+			candidates = slices.Insert(withoutLicenseType(candidates, resolved), 0, Candidate{
+				LicenseType: resolved,
+				Score:       best.Score,
+				Method:      FAMILY_MARKERS,
+				Regions:     best.Regions,
+			})
 		}
+	} else if notice, ok := matchShortNotice(content); ok {
+		candidates = slices.Insert(withoutLicenseType(candidates, notice.licenseType), 0, Candidate{
+			LicenseType: notice.licenseType,
+			Score:       1,
+			Method:      SHORT_NOTICE,
+			Regions:     noticeRegions(lines, notice.names),
+		})
+	}
 
-		if a == b {
-			return 0
-		}
+	return Detection{Candidates: candidates, Threshold: m.threshold}, nil
+}
 
-		// a < b
-		return -1
-	})
+// Match identifies the license type of the given content, see the package level Match.
+func (m Matcher) Match(content string) (LicenseType, error) {
+	detection, err := m.Detect(content)
+	if err != nil {
+		return LicenseType(-1), err
+	}
 
-	// The last item in the slice has the highest coefficient
-	// thus is the most similar, so we select it to see if
-	// the match exceeds our threshold
-	bestMatch := scores[len(scores)-1]
-	if bestMatch.distance < threshold {
-		return LicenseType(-1), DetectionFailedError
+	match, err := detection.Match()
+	if err != nil {
+		return LicenseType(-1), err
 	}
 
-	return bestMatch.licenseType, nil
+	return match.LicenseType, nil
 }
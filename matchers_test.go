@@ -2,16 +2,21 @@ package ligen
 
 import (
 	"bytes"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func buildInput(f WriteableGenerator, projectName string, holder string, startYear, endYear int, dest *bytes.Buffer) (string, error) {
-	cr, err := NewCopyright(holder, startYear, endYear)
+	cr, err := testPolicy.NewCopyright(holder, startYear, endYear)
 	if err != nil {
 		return "", err
 	}
 
-	writeable, err := f(&projectName, &cr, dest)
+	copyrights := Copyrights{cr}
+	writeable, err := f(&projectName, &copyrights, &Parameters{}, dest)
 	if err != nil {
 		return "", err
 	}
@@ -152,9 +157,314 @@ func TestMatch(t *testing.T) {
 			errorMessage: "",
 		},
 		{
-			name:         "Pass-MatchFound-GnuLesser",
+			name: "Pass-MatchFound-GnuLesser",
+			inputBuilder: func(t *testing.T, lt LicenseType) string {
+				var buf bytes.Buffer
+				generatorFunc, _ := lt.GeneratorFunc()
+				docs, err := buildWriteables(generatorFunc, "Ligen", "Max Moon", 2025, 2025, &buf)
+				if err != nil {
+					t.FailNow()
+				}
+
+				// COPYING.LESSER on its own
+				return docs[1].Content
+			},
+			expected:     GNU_LESSER_3_0_ONLY,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name: "Pass-MatchFound-GnuLesser-WithGeneral",
+			inputBuilder: func(t *testing.T, lt LicenseType) string {
+				var buf bytes.Buffer
+				generatorFunc, _ := lt.GeneratorFunc()
+				docs, err := buildWriteables(generatorFunc, "Ligen", "Max Moon", 2025, 2025, &buf)
+				if err != nil {
+					t.FailNow()
+				}
+
+				// COPYING followed by COPYING.LESSER, the way FileRepository reads the pair
+				return docs[0].Content + docs[1].Content
+			},
+			expected:     GNU_LESSER_3_0_ONLY,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-BSD-0-Clause",
+			inputBuilder: inputBuilder,
+			expected:     BSD_0_CLAUSE,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-BSD-2-Clause",
+			inputBuilder: inputBuilder,
+			expected:     BSD_2_CLAUSE,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-BSD-3-Clause",
+			inputBuilder: inputBuilder,
+			expected:     BSD_3_CLAUSE,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-BSD-4-Clause",
+			inputBuilder: inputBuilder,
+			expected:     BSD_4_CLAUSE,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-GnuGeneral-2.0",
+			inputBuilder: inputBuilder,
+			expected:     GNU_GENERAL_2_0_ONLY,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-GnuGeneral-3.0",
+			inputBuilder: inputBuilder,
+			expected:     GNU_GENERAL_3_0_ONLY,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-GnuAffero-3.0",
+			inputBuilder: inputBuilder,
+			expected:     GNU_AFFERO_3_0_ONLY,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name: "Pass-MatchFound-GnuGeneral-3.0-OrLaterReportedAsOnly",
+			inputBuilder: func(t *testing.T, lt LicenseType) string {
+				return inputBuilder(t, GNU_GENERAL_3_0_OR_LATER)
+			},
+			expected:     GNU_GENERAL_3_0_ONLY,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-ISC",
+			inputBuilder: inputBuilder,
+			expected:     ISC,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-Zlib",
+			inputBuilder: inputBuilder,
+			expected:     ZLIB,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-PostgreSQL",
+			inputBuilder: inputBuilder,
+			expected:     POSTGRESQL,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-BlueOak",
+			inputBuilder: inputBuilder,
+			expected:     BLUE_OAK_1_0_0,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-Unicode-3.0",
+			inputBuilder: inputBuilder,
+			expected:     UNICODE_3_0,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name: "Pass-MatchFound-ISC-Rewrapped",
+			inputBuilder: func(t *testing.T, lt LicenseType) string {
+				// Short licenses are easily thrown off by copyright lines and wrapping
+				// that differ from the template, so use both
+				return `ISC License
+
+Copyright (c) 2016 Joseph Birr-Pixton <jpixton@example.com>, and the Example Project Developers
+
+Permission to use, copy, modify, and/or distribute this software for any purpose with or without fee is hereby granted, provided that the above copyright notice and this permission notice appear in all copies.
+
+THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+`
+			},
+			expected:     ISC,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name: "Pass-MatchFound-Zlib-BodyOnly",
+			inputBuilder: func(t *testing.T, lt LicenseType) string {
+				return `This software is provided 'as-is', without any express or implied
+warranty. In no event will the authors be held liable for any damages
+arising from the use of this software.
+
+Permission is granted to anyone to use this software for any purpose,
+including commercial applications, and to alter it and redistribute it
+freely, subject to the following restrictions:
+
+1. The origin of this software must not be misrepresented; you must not
+   claim that you wrote the original software. If you use this software
+   in a product, an acknowledgment in the product documentation would be
+   appreciated but is not required.
+2. Altered source versions must be plainly marked as such, and must not be
+   misrepresented as being the original software.
+3. This notice may not be removed or altered from any source distribution.
+`
+			},
+			expected:     ZLIB,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-CC0-1.0",
+			inputBuilder: inputBuilder,
+			expected:     CC0_1_0,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-CC-BY-4.0",
+			inputBuilder: inputBuilder,
+			expected:     CC_BY_4_0,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-CC-BY-SA-4.0",
+			inputBuilder: inputBuilder,
+			expected:     CC_BY_SA_4_0,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name: "Pass-MatchFound-CC-BY-4.0-ShortNotice",
+			inputBuilder: func(t *testing.T, lt LicenseType) string {
+				return `Copyright (c) 2025 Max Moon
+
+This work is licensed under a Creative Commons Attribution 4.0
+International License. See https://creativecommons.org/licenses/by/4.0/`
+			},
+			expected:     CC_BY_4_0,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name: "Pass-MatchFound-CC-BY-SA-4.0-ShortNotice",
+			inputBuilder: func(t *testing.T, lt LicenseType) string {
+				return `Ligen (c) by Max Moon
+
+Ligen is licensed under a
+Creative Commons Attribution-ShareAlike 4.0 International License.`
+			},
+			expected:     CC_BY_SA_4_0,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name: "Pass-MatchFound-CC-BY-SA-4.0-ShortNotice-Abbreviated",
+			inputBuilder: func(t *testing.T, lt LicenseType) string {
+				return `The documentation in this repository is licensed under CC BY-SA 4.0.`
+			},
+			expected:     CC_BY_SA_4_0,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name: "Pass-MatchFound-CC0-1.0-ShortNotice",
+			inputBuilder: func(t *testing.T, lt LicenseType) string {
+				return `To the extent possible under law, Max Moon has waived all copyright
+and related or neighboring rights to Ligen.`
+			},
+			expected:     CC0_1_0,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-Eclipse-2.0",
+			inputBuilder: inputBuilder,
+			expected:     ECLIPSE_2_0,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name: "Pass-MatchFound-Business-Source-1.1",
+			inputBuilder: func(t *testing.T, lt LicenseType) string {
+				var buf bytes.Buffer
+				projectName := "Ligen"
+				cr := Copyrights{{Holder: "Max Moon", StartYear: 2025}}
+				params := Parameters{ChangeDate: time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC), ChangeLicense: "Apache License, Version 2.0"}
+
+				docs, err := BusinessSourceGenerator(&projectName, &cr, &params, &buf)
+				if err != nil {
+					t.FailNow()
+				}
+
+				return docs[0].Content
+			},
+			expected:     BUSINESS_SOURCE_1_1,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-Elastic-2.0",
+			inputBuilder: inputBuilder,
+			expected:     ELASTIC_2_0,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-PolyForm-Noncommercial-1.0.0",
+			inputBuilder: inputBuilder,
+			expected:     POLYFORM_NONCOMMERCIAL_1_0_0,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name:         "Pass-MatchFound-Proprietary",
 			inputBuilder: inputBuilder,
-			expected:     GNU_LESSER_3_0,
+			expected:     PROPRIETARY,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name: "Pass-MatchFound-Proprietary-CustomClause",
+			inputBuilder: func(t *testing.T, lt LicenseType) string {
+				var buf bytes.Buffer
+				projectName := "Ligen"
+				cr := Copyrights{{Holder: "Max Moon", StartYear: 2025}}
+				params := Parameters{
+					ConfidentialityClause: strings.Repeat("The Software is a trade secret of the copyright holder and must not leave the organization. ", 5),
+					ContactEmail:          "legal@example.com",
+				}
+
+				docs, err := ProprietaryGenerator(&projectName, &cr, &params, &buf)
+				if err != nil {
+					t.FailNow()
+				}
+
+				return docs[0].Content
+			},
+			expected:     PROPRIETARY,
+			threshold:    passingThreshold,
+			errorMessage: "",
+		},
+		{
+			name: "Pass-MatchFound-Proprietary-ShortNotice",
+			inputBuilder: func(t *testing.T, lt LicenseType) string {
+				return `Copyright (c) 2025 Max Moon. All rights reserved.
+Proprietary and confidential. Unauthorized copying of this file, via any medium, is strictly prohibited.`
+			},
+			expected:     PROPRIETARY,
 			threshold:    passingThreshold,
 			errorMessage: "",
 		},
@@ -184,3 +494,244 @@ func TestMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveFamily(t *testing.T) {
+	tests := []struct {
+		name        string
+		licenseType LicenseType
+		content     string
+		expected    LicenseType
+	}{
+		{
+			name:        "Pass-BSD-2-Clause-NoExtraClauses",
+			licenseType: BSD_3_CLAUSE,
+			content:     "Redistribution and use in source and binary forms, with or without modification, are permitted",
+			expected:    BSD_2_CLAUSE,
+		},
+		{
+			name:        "Pass-BSD-3-Clause-Rewrapped",
+			licenseType: BSD_2_CLAUSE,
+			content:     "3. Neither the name\n   of the copyright holder nor the names of its contributors",
+			expected:    BSD_3_CLAUSE,
+		},
+		{
+			name:        "Pass-BSD-4-Clause-AdvertisingClause",
+			licenseType: BSD_3_CLAUSE,
+			content:     "3. All advertising materials mentioning features or use of this software\n4. Neither the name of",
+			expected:    BSD_4_CLAUSE,
+		},
+		{
+			name:        "Pass-GnuAffero-NetworkInteraction",
+			licenseType: GNU_GENERAL_3_0_ONLY,
+			content:     "13. Remote Network Interaction; Use with the GNU General Public License.",
+			expected:    GNU_AFFERO_3_0_ONLY,
+		},
+		{
+			name:        "Pass-GnuGeneral-AfferoSectionOnly",
+			licenseType: GNU_AFFERO_3_0_ONLY,
+			content:     "13. Use with the GNU Affero General Public License.",
+			expected:    GNU_GENERAL_3_0_ONLY,
+		},
+		{
+			name:        "Pass-ISC-NoticeRetention",
+			licenseType: BSD_0_CLAUSE,
+			content:     "hereby granted, provided that the above copyright notice\nand this permission notice appear in all copies.",
+			expected:    ISC,
+		},
+		{
+			name:        "Pass-BSD-0-Clause-NoConditions",
+			licenseType: ISC,
+			content:     "Permission to use, copy, modify, and/or distribute this software for any\npurpose with or without fee is hereby granted.",
+			expected:    BSD_0_CLAUSE,
+		},
+		{
+			name:        "Pass-CC-BY-SA-4.0-ShareAlike",
+			licenseType: CC_BY_4_0,
+			content:     "Creative Commons Attribution-ShareAlike 4.0 International Public License",
+			expected:    CC_BY_SA_4_0,
+		},
+		{
+			name:        "Pass-CC-BY-4.0-NoShareAlike",
+			licenseType: CC_BY_SA_4_0,
+			content:     "Creative Commons Attribution 4.0 International Public License",
+			expected:    CC_BY_4_0,
+		},
+		{
+			name:        "Pass-NoFamily",
+			licenseType: MIT,
+			content:     "Neither the name of",
+			expected:    MIT,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resolved := resolveFamily(tc.licenseType, tc.content)
+
+			if resolved != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected.String(), resolved.String())
+			}
+		})
+	}
+}
+
+func TestResolveVersionChoice(t *testing.T) {
+	tests := []struct {
+		name        string
+		licenseType LicenseType
+		notice      string
+		expected    LicenseType
+	}{
+		{
+			name:        "Pass-OrLater",
+			licenseType: GNU_GENERAL_2_0_ONLY,
+			notice:      "either version 2 of the License, or\n(at your option) any later version.",
+			expected:    GNU_GENERAL_2_0_OR_LATER,
+		},
+		{
+			name:        "Pass-Only",
+			licenseType: GNU_AFFERO_3_0_OR_LATER,
+			notice:      "by the Free Software Foundation, version 3 of the License.",
+			expected:    GNU_AFFERO_3_0_ONLY,
+		},
+		{
+			name:        "Pass-NoVariants",
+			licenseType: APACHE_2_0,
+			notice:      "any later version",
+			expected:    APACHE_2_0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resolved := resolveVersionChoice(tc.licenseType, tc.notice)
+
+			if resolved != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected.String(), resolved.String())
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputBuilder func(t *testing.T) string
+		expected     []Candidate
+		errorMessage string
+	}{
+		{
+			name: "Pass-TextSimilarity",
+			inputBuilder: func(t *testing.T) string {
+				var buf bytes.Buffer
+				content, err := buildInput(MITGenerator, "Ligen", "Max Moon", 2025, 0, &buf)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return content
+			},
+			expected: []Candidate{
+				{LicenseType: MIT, Score: 1, Method: TEXT_SIMILARITY, Regions: []Region{{StartLine: 1, EndLine: 21}}},
+			},
+		},
+		{
+			name: "Pass-FamilyMarkers",
+			inputBuilder: func(t *testing.T) string {
+				var buf bytes.Buffer
+				docs, err := buildWriteables(GNULesserGenerator, "Ligen", "Max Moon", 2025, 0, &buf)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return docs[0].Content + docs[1].Content
+			},
+			expected: []Candidate{
+				{LicenseType: GNU_LESSER_3_0_ONLY, Method: FAMILY_MARKERS},
+				{LicenseType: GNU_GENERAL_3_0_ONLY, Method: TEXT_SIMILARITY},
+			},
+		},
+		{
+			name: "Pass-ShortNotice",
+			inputBuilder: func(t *testing.T) string {
+				return "Copyright (c) 2025 Max Moon\n\nThis work is licensed under CC BY 4.0.\n"
+			},
+			expected: []Candidate{
+				{LicenseType: CC_BY_4_0, Score: 1, Method: SHORT_NOTICE, Regions: []Region{{StartLine: 3, EndLine: 3}}},
+			},
+		},
+		{
+			name: "Fail-NoMatchFound",
+			inputBuilder: func(t *testing.T) string {
+				return "The dog likes to jump and play."
+			},
+			errorMessage: DetectionFailedError.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			detection, err := Detect(tc.inputBuilder(t), 0.90)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Every license is ranked, whether it matched or not
+			if len(detection.Candidates) == 0 {
+				t.Fatal("Expected candidates, got none")
+			}
+
+			for idx := 1; idx < len(detection.Candidates); idx++ {
+				if detection.Candidates[idx].Score > detection.Candidates[idx-1].Score {
+					t.Errorf("Expected candidates sorted by score, got %+v", detection.Candidates)
+					break
+				}
+			}
+
+			match, err := detection.Match()
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			if match.LicenseType != detection.Candidates[0].LicenseType {
+				t.Errorf("Expected the best candidate to match, got %s", match.LicenseType.String())
+			}
+
+			for idx, expected := range tc.expected {
+				candidate := detection.Candidates[idx]
+
+				if candidate.LicenseType != expected.LicenseType || candidate.Method != expected.Method {
+					t.Errorf("Expected %s by %s, got %s by %s", expected.LicenseType.String(), expected.Method.String(), candidate.LicenseType.String(), candidate.Method.String())
+				}
+
+				if expected.Score != 0 && candidate.Score != expected.Score {
+					t.Errorf("Expected score %f, got %f", expected.Score, candidate.Score)
+				}
+
+				if expected.Regions != nil && !reflect.DeepEqual(expected.Regions, candidate.Regions) {
+					t.Errorf("Expected regions %+v, got %+v", expected.Regions, candidate.Regions)
+				}
+			}
+		})
+	}
+}
+
+func TestCandidatesByScore(t *testing.T) {
+	// Candidates with distinct scores used to compare as equal, leaving the order up to the sort
+	candidates := []Candidate{
+		{LicenseType: MIT, Score: 0.5},
+		{LicenseType: ISC, Score: 0.9},
+		{LicenseType: ZLIB, Score: 0.7},
+		{LicenseType: APACHE_2_0, Score: 0.9},
+	}
+
+	slices.SortFunc(candidates, byScore)
+
+	expected := []LicenseType{APACHE_2_0, ISC, ZLIB, MIT}
+	for idx, licenseType := range expected {
+		if candidates[idx].LicenseType != licenseType {
+			t.Errorf("Expected %s at %d, got %s", licenseType.String(), idx, candidates[idx].LicenseType.String())
+		}
+	}
+}
@@ -5,8 +5,8 @@ import "text/template"
 // Body of text for an MIT License
 const MitTemplateBody = `MIT License
 
-Copyright (c) {{.StartYear}}{{if (gt .EndYear 0) }}-{{.EndYear}}{{end}} {{.Holder}}
-
+{{range .}}Copyright (c) {{.Years}} {{.Credit}}
+{{end}}
 Permission is hereby granted, free of charge, to any person obtaining a copy
 of this software and associated documentation files (the "Software"), to deal
 in the Software without restriction, including without limitation the rights
@@ -0,0 +1,173 @@
+package ligen
+
+import (
+	"regexp"
+	"strings"
+)
+
+// normalizer is a single step of the text normalization applied before license texts are compared
+type normalizer func(text string) string
+
+// matchingGuidelines normalizes license texts following the SPDX matching guidelines,
+// https://spdx.github.io/spdx-spec/v2.3/license-matching-guidelines-and-templates/
+// Steps run in order, line based steps come before whitespace is collapsed and words
+// spanning a line break are only unified after.
+var matchingGuidelines = []normalizer{
+	removeTemplateActions,
+	removeCommentMarkers,
+	removeCopyrightLines,
+	removeListMarkers,
+	strings.ToLower,
+	normalizeQuotes,
+	normalizeDashes,
+	collapseWhitespace,
+	normalizeEquivalentWords,
+}
+
+// NormalizeLicenseText normalizes a license text so texts that only differ in ways the
+// SPDX matching guidelines consider equivalent compare equal. Template placeholders,
+// copyright lines, comment and list markers are removed, quotes, dashes and the spelling
+// of equivalent words like "licence" and "license" are unified, the text is lowercased and
+// runs of whitespace are collapsed.
+func NormalizeLicenseText(text string) string {
+	for _, normalize := range matchingGuidelines {
+		text = normalize(text)
+	}
+
+	return text
+}
+
+var templateActionPattern = regexp.MustCompile(`{{[^}]*}}`)
+
+// removeTemplateActions drops the placeholders of license templates, e.g. "{{.Holder}}"
+func removeTemplateActions(text string) string {
+	return templateActionPattern.ReplaceAllString(text, "")
+}
+
+// copyrightLinePattern matches copyright lines, which differ between every project, once the
+// placeholders of the template have been removed, e.g. "Copyright (c) 2025 Max Moon" and "Copyright (c) ".
+// Lines that merely start with the word, e.g. "COPYRIGHT HOLDERS BE LIABLE", are left alone.
+var copyrightLinePattern = regexp.MustCompile(`(?i)^[ \t]*(?:copyright(?:[ \t]*(?:\(c\)|©|\d{4}|:)|[ \t]*$)|©|\(c\)[ \t]*\d{4})`)
+
+// removeCopyrightLines drops copyright lines
+func removeCopyrightLines(text string) string {
+	return replaceLines(text, func(line string) string {
+		if copyrightLinePattern.MatchString(line) {
+			return ""
+		}
+
+		return line
+	})
+}
+
+// commentMarkerPattern matches the markers of a license text pasted into a source code comment
+var commentMarkerPattern = regexp.MustCompile(`^[ \t]*(?:/\*+|\*+/|//+|#+|\*|--|;+)[ \t]?`)
+
+// removeCommentMarkers drops comment markers at the start of lines
+func removeCommentMarkers(text string) string {
+	return replaceLines(text, func(line string) string {
+		return commentMarkerPattern.ReplaceAllLiteralString(line, "")
+	})
+}
+
+// listMarkerPattern matches the bullet, number or letter of a list item followed by a space,
+// e.g. "-", "1.", "(a)" or "iv)"
+var listMarkerPattern = regexp.MustCompile(`^[ \t]*(?:[-*•·]|\(?(?:\d{1,2}|[a-zA-Z]|[ivxIVX]{1,4})[.)])[ \t]+`)
+
+// removeListMarkers drops list markers at the start of lines
+func removeListMarkers(text string) string {
+	return replaceLines(text, func(line string) string {
+		return listMarkerPattern.ReplaceAllLiteralString(line, "")
+	})
+}
+
+// replaceLines replaces every line of the text with the result of replace
+func replaceLines(text string, replace func(line string) string) string {
+	lines := strings.Split(text, "\n")
+	for idx, line := range lines {
+		lines[idx] = replace(line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+var quoteReplacer = strings.NewReplacer(
+	"“", "'", "”", "'", "„", "'",
+	"‘", "'", "’", "'", "‚", "'",
+	"«", "'", "»", "'",
+	"`", "'", "\"", "'",
+)
+
+// normalizeQuotes treats every kind of quote as a single quote
+func normalizeQuotes(text string) string {
+	return quoteReplacer.Replace(text)
+}
+
+var dashReplacer = strings.NewReplacer(
+	"‐", "-", "‑", "-", "‒", "-",
+	"–", "-", "—", "-", "―", "-", "−", "-",
+)
+
+// normalizeDashes treats every kind of hyphen and dash as a hyphen
+func normalizeDashes(text string) string {
+	return dashReplacer.Replace(text)
+}
+
+// equivalentWords lists the spellings the SPDX matching guidelines consider equivalent,
+// the first of each pair is replaced by the second
+var equivalentWords = strings.NewReplacer(
+	"acknowledgment", "acknowledgement",
+	"analogue", "analog",
+	"analyse", "analyze",
+	"artefact", "artifact",
+	"authorisation", "authorization",
+	"authorised", "authorized",
+	"calibre", "caliber",
+	"cancelled", "canceled",
+	"capitalisation", "capitalization",
+	"catalogue", "catalog",
+	"categorise", "categorize",
+	"centre", "center",
+	"copyright holder", "copyright owner",
+	"emphasised", "emphasized",
+	"favour", "favor",
+	"fulfil ", "fulfill ",
+	"fulfilment", "fulfillment",
+	"https://", "http://",
+	"initialise", "initialize",
+	"judgement", "judgment",
+	"labelling", "labeling",
+	"labour", "labor",
+	"licence", "license",
+	"maximise", "maximize",
+	"modelled", "modeled",
+	"modelling", "modeling",
+	"non-commercial", "noncommercial",
+	"offence", "offense",
+	"optimise", "optimize",
+	"organisation", "organization",
+	"organise", "organize",
+	"per cent", "percent",
+	"practise", "practice",
+	"programme", "program",
+	"realise", "realize",
+	"recognise", "recognize",
+	"signalling", "signaling",
+	"sub-license", "sublicense",
+	"sub license", "sublicense",
+	"sub-licence", "sublicense",
+	"sub licence", "sublicense",
+	"utilisation", "utilization",
+	"whilst", "while",
+	"wilful", "willful",
+)
+
+// normalizeEquivalentWords replaces spelling variants of the same word, e.g. "licence" and "license"
+func normalizeEquivalentWords(text string) string {
+	return equivalentWords.Replace(text)
+}
+
+// collapseWhitespace collapses runs of whitespace so texts wrapped at different widths compare equal
+func collapseWhitespace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
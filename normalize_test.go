@@ -0,0 +1,139 @@
+package ligen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeLicenseText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Pass-Whitespace",
+			input:    "Permission is hereby granted,\n  free of charge,\tto any person",
+			expected: "permission is hereby granted, free of charge, to any person",
+		},
+		{
+			name:     "Pass-Quotes",
+			input:    "THE SOFTWARE IS PROVIDED “AS IS” and ‘AS AVAILABLE’, the \"Software\"",
+			expected: "the software is provided 'as is' and 'as available', the 'software'",
+		},
+		{
+			name:     "Pass-Dashes",
+			input:    "non‑infringement — see below",
+			expected: "non-infringement - see below",
+		},
+		{
+			name:     "Pass-ListMarkers",
+			input:    "1. Redistributions of source code\n(b) Redistributions in binary form\n  * Neither the name\n- Attribution\niv) Notices",
+			expected: "redistributions of source code redistributions in binary form neither the name attribution notices",
+		},
+		{
+			name:     "Pass-CopyrightLines",
+			input:    "MIT License\n\nCopyright (c) 2025 Max Moon\n© 2024 Jelly\nCopyright 2019-present Peanut Butter\n\nAUTHORS OR COPYRIGHT HOLDERS BE LIABLE",
+			expected: "mit license authors or copyright owners be liable",
+		},
+		{
+			name:     "Pass-TemplateActions",
+			input:    "MIT License\n\n{{range .}}Copyright (c) {{.Years}} {{.Holder}}\n{{end}}\nPermission is hereby granted",
+			expected: "mit license permission is hereby granted",
+		},
+		{
+			name:     "Pass-CommentMarkers",
+			input:    "/*\n * Licensed under the Apache License\n */\n// you may not use this file\n# except in compliance",
+			expected: "licensed under the apache license you may not use this file except in compliance",
+		},
+		{
+			name:     "Pass-EquivalentWords",
+			input:    "This Licence lets you sub-license the programme, see https://example.com, whilst the copyright\nholder",
+			expected: "this license lets you sublicense the program, see http://example.com, while the copyright owner",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			normalized := NormalizeLicenseText(tc.input)
+
+			if normalized != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, normalized)
+			}
+		})
+	}
+}
+
+func TestLicenseTypeCompareNormalized(t *testing.T) {
+	var dest strings.Builder
+	if err := MITTemplate.Execute(&dest, Copyrights{{Holder: "Max Moon", StartYear: 2025}}); err != nil {
+		t.Fatal(err)
+	}
+
+	rendered := dest.String()
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "Pass-Rendered",
+			input: rendered,
+		},
+		{
+			name:  "Pass-Rewrapped",
+			input: rewrap(rendered, 40),
+		},
+		{
+			name:  "Pass-SmartQuotesAndSpelling",
+			input: strings.NewReplacer(`"AS IS"`, "“AS IS”", "sublicense", "sub-licence").Replace(rendered),
+		},
+		{
+			name:  "Pass-OtherCopyright",
+			input: strings.Replace(rendered, "Copyright (c) 2025 Max Moon", "Copyright © 1999-2024 Peanut Butter Inc. <pb@example.com>", 1),
+		},
+		{
+			name:  "Pass-Commented",
+			input: "// " + strings.ReplaceAll(rendered, "\n", "\n// "),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			coefficient, err := MIT.Compare(tc.input, SorensonDiceCoefficient)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if coefficient != 1 {
+				t.Errorf("Expected an exact match, got similarity of %f", coefficient)
+			}
+		})
+	}
+}
+
+// rewrap wraps the paragraphs of a text at the given width
+func rewrap(text string, width int) string {
+	var wrapped strings.Builder
+
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		lineLength := 0
+
+		for _, word := range strings.Fields(paragraph) {
+			if lineLength > 0 && lineLength+len(word) >= width {
+				wrapped.WriteString("\n")
+				lineLength = 0
+			} else if lineLength > 0 {
+				wrapped.WriteString(" ")
+				lineLength++
+			}
+
+			wrapped.WriteString(word)
+			lineLength += len(word)
+		}
+
+		wrapped.WriteString("\n\n")
+	}
+
+	return wrapped.String()
+}
@@ -5,13 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
+	"time"
 )
 
 var (
-	noMatchError           = errors.New("line does not match copyright pattern")
-	copyrightNotFoundError = errors.New("no copyright line found")
+	noMatchError                   = errors.New("line does not match copyright pattern")
+	copyrightNotFoundError         = errors.New("no copyright line found")
+	licenseIdentifierNotFoundError = errors.New("no SPDX-License-Identifier found")
 )
 
 // ParseProjectNameFromNotice extracts the project name from the first line of a NOTICE file.
@@ -34,63 +35,285 @@ func ParseProjectNameFromNotice(document string) (string, error) {
 	return firstLine, nil
 }
 
+var licenseIdentifierPattern = regexp.MustCompile(`SPDX-License-Identifier:\s*(.+?)\s*$`)
+
+// ParseLicenseIdentifier finds the first SPDX-License-Identifier line of a document and parses its expression.
+func ParseLicenseIdentifier(document string) (Expression, error) {
+	for _, line := range strings.Split(document, "\n") {
+		matches := licenseIdentifierPattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if matches != nil {
+			return ParseExpression(matches[1])
+		}
+	}
+
+	return Expression{}, licenseIdentifierNotFoundError
+}
+
+var secondaryLicensePattern = regexp.MustCompile(`(?i)secondary licenses when the conditions for such availability set forth in the eclipse public license,? v\. 2\.0 are satisfied:\s*(.+?)\.?"?$`)
+
+// ParseSecondaryLicenseFromNotice extracts the Secondary License declared in an Eclipse Public License 2.0
+// notice, following the form of Exhibit A. Returns an empty string if the notice doesn't declare one.
+func ParseSecondaryLicenseFromNotice(document string) string {
+	for _, paragraph := range strings.Split(strings.ReplaceAll(document, "\r\n", "\n"), "\n\n") {
+		collapsed := strings.Join(strings.Fields(paragraph), " ")
+
+		matches := secondaryLicensePattern.FindStringSubmatch(collapsed)
+		if matches != nil {
+			return matches[1]
+		}
+	}
+
+	return ""
+}
+
+var (
+	businessSourceParameterPattern = regexp.MustCompile(`^(Licensor|Licensed Work|Additional Use Grant|Change Date|Change License):\s*(.*)$`)
+	licensedWorkCopyrightPattern   = regexp.MustCompile(`\s*The Licensed Work is \([Cc]\).*$`)
+)
+
+// ParseBusinessSourceParameters extracts the parameters from the Parameters section of a Business Source License.
+// Values that are wrapped onto indented lines are joined back together, and the copyright sentence that
+// follows the Licensed Work is dropped since it's rendered from the Copyright.
+func ParseBusinessSourceParameters(document string) (Parameters, error) {
+	values := make(map[string]string)
+	current := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(document))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if matches := businessSourceParameterPattern.FindStringSubmatch(line); matches != nil {
+			current = matches[1]
+			values[current] = strings.TrimSpace(matches[2])
+			continue
+		}
+
+		// Continuation lines are indented, anything else ends the current value
+		if current != "" && strings.TrimSpace(line) != "" && strings.TrimLeft(line, " \t") != line {
+			values[current] = strings.TrimSpace(values[current] + " " + strings.TrimSpace(line))
+			continue
+		}
+
+		current = ""
+	}
+
+	parameters := Parameters{
+		Licensor:           values["Licensor"],
+		LicensedWork:       licensedWorkCopyrightPattern.ReplaceAllString(values["Licensed Work"], ""),
+		AdditionalUseGrant: values["Additional Use Grant"],
+		ChangeLicense:      values["Change License"],
+	}
+
+	if changeDate := values["Change Date"]; changeDate != "" {
+		date, err := time.Parse("2006-01-02", changeDate)
+		if err != nil {
+			return Parameters{}, InvalidChangeDateError
+		}
+
+		parameters.ChangeDate = date
+	}
+
+	return parameters, nil
+}
+
+var proprietaryContactPattern = regexp.MustCompile(`^For licensing inquiries, contact (\S+?)\.?$`)
+
+// ParseProprietaryParameters extracts the confidentiality clause and contact email from a proprietary license.
+// The confidentiality clause is whatever sits between the reservation of rights and the warranty disclaimer.
+func ParseProprietaryParameters(document string) Parameters {
+	var parameters Parameters
+
+	paragraphs := strings.Split(strings.ReplaceAll(document, "\r\n", "\n"), "\n\n")
+	reservation, disclaimer := -1, -1
+
+	for idx, paragraph := range paragraphs {
+		collapsed := strings.Join(strings.Fields(paragraph), " ")
+
+		switch {
+		case strings.Contains(collapsed, "without the prior written permission of the copyright holder"):
+			reservation = idx
+		case strings.HasPrefix(collapsed, "THE SOFTWARE IS PROVIDED"):
+			disclaimer = idx
+		default:
+			if matches := proprietaryContactPattern.FindStringSubmatch(collapsed); matches != nil {
+				parameters.ContactEmail = matches[1]
+			}
+		}
+	}
+
+	if reservation != -1 && disclaimer > reservation+1 {
+		clause := strings.Join(paragraphs[reservation+1:disclaimer], " ")
+		parameters.ConfidentialityClause = strings.Join(strings.Fields(clause), " ")
+	}
+
+	return parameters
+}
+
+// parseParameters extracts the license specific parameters of the license type
+// from the license and notice content.
+func parseParameters(licenseType LicenseType, license string, notice string) (Parameters, error) {
+	switch licenseType {
+	case ECLIPSE_2_0:
+		return Parameters{SecondaryLicense: ParseSecondaryLicenseFromNotice(notice)}, nil
+	case BUSINESS_SOURCE_1_1:
+		return ParseBusinessSourceParameters(license)
+	case PROPRIETARY:
+		return ParseProprietaryParameters(license), nil
+	default:
+		return Parameters{}, nil
+	}
+}
+
 // ParseDocForCopyright scans a document line by line and returns the first valid copyright it finds.
+//
+// Deprecated: use ParseDocForCopyrights, which returns every copyright of the copyright block.
 func ParseDocForCopyright(content string) (Copyright, error) {
-	reader := strings.NewReader(content)
-	scanner := bufio.NewScanner(reader)
+	copyrights, err := ParseDocForCopyrights(content)
+	if err != nil {
+		return Copyright{}, err
+	}
+
+	return copyrights[0], nil
+}
+
+// ParseDocForCopyrights scans a document line by line and returns every copyright in the block of
+// copyright lines that starts at the first valid one. Blank lines and copyright-like lines that can't
+// be parsed are allowed within the block, any other line ends it so copyrights quoted further down,
+// like in the license text, are left out. Use ScanCopyrights to find the lines that couldn't be parsed.
+func ParseDocForCopyrights(content string) (Copyrights, error) {
+	var copyrights Copyrights
 
+	scanner := bufio.NewScanner(strings.NewReader(content))
 	for scanner.Scan() {
-		line := scanner.Text()
-		copyright, err := ParseCopyright(line)
+		// PolyForm licenses carry the copyright in a "Required Notice:" line
+		line := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "Required Notice:")
 
+		copyright, err := ParseCopyright(line)
 		if err == nil {
-			return copyright, nil
+			copyrights = append(copyrights, copyright)
+			continue
 		}
+
+		if len(copyrights) > 0 && strings.TrimSpace(line) != "" && !looksLikeCopyright(line) {
+			break
+		}
+	}
+
+	if len(copyrights) == 0 {
+		return nil, copyrightNotFoundError
 	}
 
-	return Copyright{}, copyrightNotFoundError
+	return copyrights, nil
 }
 
-// ParseCopyright parses a copyright line and extracts the holder name and year range.
-// Expects format: "Copyright [©|©] YYYY[-YYYY] Holder Name"
-func ParseCopyright(line string) (Copyright, error) {
-	line = strings.TrimSpace(line)
+var (
+	// The line starts with "Copyright", "©" or "(c)", in any combination, optionally followed by a colon
+	copyrightPattern         = regexp.MustCompile(`^(?:(?i:copyright)\s*:?\s*(?:(?:©|\([Cc]\))\s*)?|(?:©|\([Cc]\))\s*)(` + yearsPattern + `)[,:]?\s+(.+?)\s*$`)
+	copyrightLikePattern     = regexp.MustCompile(`^(?:(?i:copyright)\s*:?\s*(?:©|\([Cc]\))?\s*\d|©|\([Cc]\)\s*\d)`)
+	allRightsReservedPattern = regexp.MustCompile(`(?i)[\s,;]*(\.)?\s*all rights reserved\.?$`)
+	contributorsPattern      = regexp.MustCompile(`(?i)[\s,]+(?:and|&)\s+(?:its |other |all )?contributors\.?$`)
+	holderEmailPattern       = regexp.MustCompile(`\s*<([^<>\s]+@[^<>\s]+)>$`)
+	// Company names that end with an abbreviation keep their period
+	holderAbbreviationPattern = regexp.MustCompile(`\b(?:Inc|Ltd|Co|Corp)$`)
+)
+
+// looksLikeCopyright reports whether a line is meant as a copyright statement: it starts with
+// "Copyright" followed by a year, "©" or "(c)" followed by a year.
+func looksLikeCopyright(line string) bool {
+	return copyrightLikePattern.MatchString(strings.TrimSpace(line))
+}
+
+// parseHolder splits what follows the years of a copyright line into the holder, their email
+// and whether their contributors are credited as well, dropping any "All rights reserved."
+func parseHolder(text string) (Copyright, error) {
+	var copyright Copyright
+
+	if matches := allRightsReservedPattern.FindStringSubmatchIndex(text); matches != nil {
+		text = text[:matches[0]]
+		if matches[2] != -1 && holderAbbreviationPattern.MatchString(text) {
+			text += "."
+		}
+	}
+
+	for range 2 {
+		if loc := contributorsPattern.FindStringIndex(text); loc != nil {
+			copyright.Contributors = true
+			text = text[:loc[0]]
+		}
 
-	pattern := `^Copyright\s*(?:\([Cc]\)\s*)?(\d{4})(?:-(\d{4}))?\s+(.+?)\s*$`
+		if matches := holderEmailPattern.FindStringSubmatchIndex(text); matches != nil {
+			copyright.Email = text[matches[2]:matches[3]]
+			text = text[:matches[0]]
+		}
+	}
 
-	re := regexp.MustCompile(pattern)
+	copyright.Holder = strings.Trim(strings.TrimPrefix(text, "by "), " \t,;")
+	if copyright.Holder == "" {
+		return Copyright{}, EmptyHolderError
+	}
 
-	matches := re.FindStringSubmatch(line)
+	return copyright, nil
+}
+
+// ParseCopyright parses a copyright line and extracts the holder, their email and the years.
+// Accepts lines such as "Copyright (c) 2020 Holder Name", "© 2020 Holder Name", "(c) 2020 Holder Name"
+// or "Copyright: 2020 Holder Name", where the years are a single year, a range, a range that runs
+// to the present, or a comma separated list of those, e.g. "2018, 2020-2022, 2024".
+// The holder can be followed by an email in angle brackets, "and contributors" and "All rights reserved.".
+func ParseCopyright(line string) (Copyright, error) {
+	line = strings.TrimSpace(line)
+
+	matches := copyrightPattern.FindStringSubmatch(line)
 	if matches == nil {
 		return Copyright{}, noMatchError
 	}
 
-	// Parse StartYear (should always be present)
-	startYear, err := strconv.Atoi(matches[1])
+	years, err := ParseYears(matches[1])
 	if err != nil {
-		return Copyright{}, fmt.Errorf("invalid start year: %s", matches[1])
+		return Copyright{}, err
 	}
 
-	// Parse EndYear (optional)
-	var endYear int
-	if matches[2] != "" {
-		endYear, err = strconv.Atoi(matches[2])
-		if err != nil {
-			return Copyright{}, fmt.Errorf("invalid end year: %s", matches[2])
-		}
+	copyright, err := parseHolder(matches[2])
+	if err != nil {
+		return Copyright{}, err
 	}
 
-	holder := matches[3]
-
-	license := Copyright{
-		Holder:    holder,
-		StartYear: startYear,
-		EndYear:   endYear,
+	if err := copyright.SetYears(years); err != nil {
+		return Copyright{}, err
 	}
 
-	if err := license.Validate(); err != nil {
-		return Copyright{}, err
+	return copyright, nil
+}
+
+// UnparsedCopyright is a line that looks like a copyright statement but couldn't be parsed.
+type UnparsedCopyright struct {
+	// Line is the 1-based line the statement is on, 0 if it couldn't be located
+	Line int
+	Text string
+	Err  error
+}
+
+// ScanCopyrights parses every copyright statement in a document. Lines that look like a copyright
+// statement but can't be parsed are returned as well, so they can be reported instead of being skipped.
+func ScanCopyrights(content string) (Copyrights, []UnparsedCopyright) {
+	var copyrights Copyrights
+	var unparsed []UnparsedCopyright
+
+	for idx, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		// PolyForm licenses carry the copyright in a "Required Notice:" line
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "Required Notice:"))
+		if !looksLikeCopyright(line) {
+			continue
+		}
+
+		copyright, err := ParseCopyright(line)
+		if err != nil {
+			unparsed = append(unparsed, UnparsedCopyright{Line: idx + 1, Text: line, Err: err})
+			continue
+		}
+
+		copyrights = append(copyrights, copyright)
 	}
 
-	return license, nil
+	return copyrights, unparsed
 }
@@ -4,15 +4,17 @@ import (
 	"bytes"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func buildWriteables(f WriteableGenerator, projectName string, holder string, startYear, endYear int, dest *bytes.Buffer) ([]Writeable, error) {
-	cr, err := NewCopyright(holder, startYear, endYear)
+	cr, err := testPolicy.NewCopyright(holder, startYear, endYear)
 	if err != nil {
 		return nil, err
 	}
 
-	writeable, err := f(&projectName, &cr, dest)
+	copyrights := Copyrights{cr}
+	writeable, err := f(&projectName, &copyrights, &Parameters{}, dest)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +87,25 @@ func TestParseDoc(t *testing.T) {
 		{
 			name: "Passing-GNULesser",
 			inputBuilder: func(t *testing.T, startYear, endYear int, holder string) string {
-				docs := builder(t, GNU_LESSER_3_0, startYear, endYear, holder, "Ligen")
+				docs := builder(t, GNU_LESSER_3_0_OR_LATER, startYear, endYear, holder, "Ligen")
+				return docs[2].Content
+			},
+			input:        commonInput,
+			errorMessage: "",
+		},
+		{
+			name: "Passing-BSD-4-Clause",
+			inputBuilder: func(t *testing.T, startYear, endYear int, holder string) string {
+				docs := builder(t, BSD_4_CLAUSE, startYear, endYear, holder, "Ligen")
+				return docs[0].Content
+			},
+			input:        commonInput,
+			errorMessage: "",
+		},
+		{
+			name: "Passing-GNUGeneral",
+			inputBuilder: func(t *testing.T, startYear, endYear int, holder string) string {
+				docs := builder(t, GNU_GENERAL_3_0_OR_LATER, startYear, endYear, holder, "Ligen")
 				return docs[1].Content
 			},
 			input:        commonInput,
@@ -121,6 +141,67 @@ func TestParseDoc(t *testing.T) {
 	}
 }
 
+func TestParseDocForCopyrights(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		expectedOutput Copyrights
+		errorMessage   string
+	}{
+		{
+			name:  "Pass-MultipleHolders",
+			input: "MIT License\n\nCopyright (c) 2019 Alice\nCopyright (c) 2021-2024 Bob\n\nPermission is hereby granted\n",
+			expectedOutput: Copyrights{
+				{Holder: "Alice", StartYear: 2019},
+				{Holder: "Bob", StartYear: 2021, EndYear: 2024},
+			},
+		},
+		{
+			name:  "Pass-StopsAtLicenseText",
+			input: "Ligen\nCopyright (C) 2019 Alice\n\nThis program is free software\n\nCopyright (C) 2007 Free Software Foundation, Inc.\n",
+			expectedOutput: Copyrights{
+				{Holder: "Alice", StartYear: 2019},
+			},
+		},
+		{
+			name:  "Pass-SkipsUnparseableLine",
+			input: "Copyright (c) 2019 Alice\nCopyright (c) 2024-2019 Carol\nCopyright (c) 2021 Bob\n\nPermission is hereby granted\n",
+			expectedOutput: Copyrights{
+				{Holder: "Alice", StartYear: 2019},
+				{Holder: "Bob", StartYear: 2021},
+			},
+		},
+		{
+			name:  "Pass-RequiredNotice",
+			input: "Required Notice: Copyright 2019 Alice\nRequired Notice: Copyright 2021 Bob\n",
+			expectedOutput: Copyrights{
+				{Holder: "Alice", StartYear: 2019},
+				{Holder: "Bob", StartYear: 2021},
+			},
+		},
+		{
+			name:         "Fail-NoCopyright",
+			input:        "Ligen\n",
+			errorMessage: copyrightNotFoundError.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			copyrights, err := ParseDocForCopyrights(tc.input)
+
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			if !reflect.DeepEqual(tc.expectedOutput, copyrights) {
+				t.Errorf("Expected %v, got %v", tc.expectedOutput, copyrights)
+			}
+		})
+	}
+}
+
 func TestParseCopyright(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -140,6 +221,110 @@ func TestParseCopyright(t *testing.T) {
 			},
 			errorMessage: "",
 		},
+		{
+			name: "Passing-YearList",
+			inputBuilder: func() string {
+				return "Copyright (c) 2018, 2020-2022, 2024 Max Moon"
+			},
+			expectedOutput: Copyright{
+				Holder:    "Max Moon",
+				StartYear: 2018,
+				EndYear:   2024,
+				set:       YearSet{{Start: 2018}, {Start: 2020, End: 2022}, {Start: 2024}},
+			},
+			errorMessage: "",
+		},
+		{
+			name: "Passing-Present",
+			inputBuilder: func() string {
+				return "Copyright 2019-present Max Moon"
+			},
+			expectedOutput: Copyright{
+				Holder:    "Max Moon",
+				StartYear: 2019,
+				set:       YearSet{{Start: 2019, Present: true}},
+			},
+			errorMessage: "",
+		},
+		{
+			name: "Passing-CopyrightSymbol",
+			inputBuilder: func() string {
+				return "© 2020 Max Moon"
+			},
+			expectedOutput: Copyright{Holder: "Max Moon", StartYear: 2020},
+		},
+		{
+			name: "Passing-CopyrightWordAndSymbol",
+			inputBuilder: func() string {
+				return "Copyright © 2020-2024 Max Moon"
+			},
+			expectedOutput: Copyright{Holder: "Max Moon", StartYear: 2020, EndYear: 2024},
+		},
+		{
+			name: "Passing-ParenthesizedC",
+			inputBuilder: func() string {
+				return "(c) 2020 Max Moon"
+			},
+			expectedOutput: Copyright{Holder: "Max Moon", StartYear: 2020},
+		},
+		{
+			name: "Passing-Colon",
+			inputBuilder: func() string {
+				return "Copyright: 2020 Max Moon"
+			},
+			expectedOutput: Copyright{Holder: "Max Moon", StartYear: 2020},
+		},
+		{
+			name: "Passing-AllRightsReserved",
+			inputBuilder: func() string {
+				return "Copyright (c) 2020 Acme. All rights reserved."
+			},
+			expectedOutput: Copyright{Holder: "Acme", StartYear: 2020},
+		},
+		{
+			name: "Passing-AllRightsReservedAbbreviation",
+			inputBuilder: func() string {
+				return "Copyright (c) 2020 Acme Inc. All Rights Reserved."
+			},
+			expectedOutput: Copyright{Holder: "Acme Inc.", StartYear: 2020},
+		},
+		{
+			name: "Passing-Email",
+			inputBuilder: func() string {
+				return "Copyright 2020 Max Moon <max@example.com>"
+			},
+			expectedOutput: Copyright{Holder: "Max Moon", Email: "max@example.com", StartYear: 2020},
+		},
+		{
+			name: "Passing-Contributors",
+			inputBuilder: func() string {
+				return "Copyright (c) 2020 Max Moon and contributors"
+			},
+			expectedOutput: Copyright{Holder: "Max Moon", Contributors: true, StartYear: 2020},
+		},
+		{
+			name: "Passing-EmailContributorsAndRights",
+			inputBuilder: func() string {
+				return "Copyright (C) 2018, 2020 Max Moon <max@example.com> and other contributors, all rights reserved"
+			},
+			expectedOutput: Copyright{Holder: "Max Moon", Email: "max@example.com", Contributors: true, StartYear: 2018, EndYear: 2020, set: YearSet{{Start: 2018}, {Start: 2020}}},
+		},
+		{
+			name: "Failing-EmailOnly",
+			inputBuilder: func() string {
+				return "Copyright 2020 <max@example.com>"
+			},
+			expectedOutput: Copyright{},
+			errorMessage:   EmptyHolderError.Error(),
+		},
+		{
+			name: "Failing-YearsOverlap",
+			inputBuilder: func() string {
+				return "Copyright 2018-2021, 2020 Max Moon"
+			},
+			expectedOutput: Copyright{},
+			errorMessage:   OverlappingYearsError.Error(),
+		},
 		{
 			name: "Failing",
 			inputBuilder: func() string {
@@ -165,3 +350,150 @@ func TestParseCopyright(t *testing.T) {
 		})
 	}
 }
+
+func TestScanCopyrights(t *testing.T) {
+	content := `Copyright (c) 2019 Max Moon
+© 2021 Jelly <jelly@example.com>
+Copyright 20XX Peanut Butter
+Copyright: soon
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND. IN NO EVENT SHALL THE
+COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM.
+`
+
+	copyrights, unparsed := ScanCopyrights(content)
+
+	expectedCopyrights := Copyrights{
+		{Holder: "Max Moon", StartYear: 2019},
+		{Holder: "Jelly", Email: "jelly@example.com", StartYear: 2021},
+	}
+	if !reflect.DeepEqual(expectedCopyrights, copyrights) {
+		t.Errorf("Expected %+v, got %+v", expectedCopyrights, copyrights)
+	}
+
+	expectedUnparsed := []UnparsedCopyright{
+		{Line: 3, Text: "Copyright 20XX Peanut Butter", Err: noMatchError},
+	}
+	if !reflect.DeepEqual(expectedUnparsed, unparsed) {
+		t.Errorf("Expected %+v, got %+v", expectedUnparsed, unparsed)
+	}
+}
+
+func TestParseSecondaryLicenseFromNotice(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputBuilder func(t *testing.T) string
+		expected     string
+	}{
+		{
+			name: "Passing-Rendered",
+			inputBuilder: func(t *testing.T) string {
+				var dest bytes.Buffer
+				if err := EclipseNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: "Ligen", Copyrights: Copyrights{{StartYear: 2024, Holder: "Max Moon"}}, SecondaryLicense: GNUGeneral2SecondaryLicense}); err != nil {
+					t.FailNow()
+				}
+
+				return dest.String()
+			},
+			expected: GNUGeneral2SecondaryLicense,
+		},
+		{
+			name: "Passing-ExhibitAQuotedAndWrapped",
+			inputBuilder: func(t *testing.T) string {
+				return `"This Source Code may also be made available under the following
+Secondary Licenses when the conditions for such availability set forth
+in the Eclipse Public License, v. 2.0 are satisfied: GNU General Public
+License, version 2 or later."`
+			},
+			expected: "GNU General Public License, version 2 or later",
+		},
+		{
+			name: "Passing-NoDeclaration",
+			inputBuilder: func(t *testing.T) string {
+				var dest bytes.Buffer
+				if err := EclipseNoticeTemplate.Execute(&dest, &NoticeInput{ProjectName: "Ligen", Copyrights: Copyrights{{StartYear: 2024, Holder: "Max Moon"}}}); err != nil {
+					t.FailNow()
+				}
+
+				return dest.String()
+			},
+			expected: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			secondaryLicense := ParseSecondaryLicenseFromNotice(tc.inputBuilder(t))
+
+			if secondaryLicense != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, secondaryLicense)
+			}
+		})
+	}
+}
+
+func TestParseBusinessSourceParameters(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expected     Parameters
+		errorMessage string
+	}{
+		{
+			name: "Passing",
+			input: `Business Source License 1.1
+
+Parameters
+
+Licensor:             Peanut Butter Inc.
+Licensed Work:        Ligen 1.0
+                      The Licensed Work is (c) 2024 Peanut Butter Inc.
+Additional Use Grant: You may make production use of the Licensed Work,
+                      provided Your use does not include offering the
+                      Licensed Work to third parties on a hosted basis.
+Change Date:          2028-06-01
+Change License:       Apache License, Version 2.0
+
+For information about alternative licensing arrangements for the Licensed Work,
+please contact licensing@example.com.
+
+Notice
+`,
+			expected: Parameters{
+				Licensor:           "Peanut Butter Inc.",
+				LicensedWork:       "Ligen 1.0",
+				AdditionalUseGrant: "You may make production use of the Licensed Work, provided Your use does not include offering the Licensed Work to third parties on a hosted basis.",
+				ChangeDate:         time.Date(2028, 6, 1, 0, 0, 0, 0, time.UTC),
+				ChangeLicense:      "Apache License, Version 2.0",
+			},
+			errorMessage: "",
+		},
+		{
+			name: "Failing-ChangeDateNotADate",
+			input: `Licensor:             Peanut Butter Inc.
+Change Date:          Four years from the date the Licensed Work is published.
+Change License:       MPL 2.0
+`,
+			expected:     Parameters{},
+			errorMessage: InvalidChangeDateError.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parameters, err := ParseBusinessSourceParameters(tc.input)
+
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			if !reflect.DeepEqual(tc.expected, parameters) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, parameters)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package ligen
+
+import (
+	"strings"
+	"time"
+)
+
+// ValidationPolicy configures how copyrights and project names are validated.
+// Start from DefaultValidationPolicy and override what needs to change, e.g. a fixed Clock
+// in tests or a larger MaxYearsPast for projects older than 50 years.
+type ValidationPolicy struct {
+	// Clock returns the current time, copyright years are validated against its year.
+	// time.Now is used when nil.
+	Clock func() time.Time
+	// MaxYearsPast is the maximum amount of time in years that a copyright can be backdated
+	MaxYearsPast int
+	// MaxNameLength is the maximum amount of chars a project name or copyright holder can contain
+	MaxNameLength int
+	// AllowFutureEndYears lets a copyright end after the current year
+	AllowFutureEndYears bool
+}
+
+// DefaultValidationPolicy returns the policy New and NewCopyright validate with.
+func DefaultValidationPolicy() ValidationPolicy {
+	return ValidationPolicy{
+		Clock:               time.Now,
+		MaxYearsPast:        MAX_YEARS_PAST,
+		MaxNameLength:       MAX_NAME_LENGTH,
+		AllowFutureEndYears: true,
+	}
+}
+
+// currentYear returns the year of the policy's clock
+func (p ValidationPolicy) currentYear() int {
+	if p.Clock == nil {
+		return time.Now().Year()
+	}
+
+	return p.Clock().Year()
+}
+
+// validateStartYear checks the year is not in the future and not backdated more than MaxYearsPast
+func (p ValidationPolicy) validateStartYear(year int) error {
+	currentYear := p.currentYear()
+
+	if year > currentYear {
+		return StartYearTooNewError
+	}
+
+	if year < currentYear-p.MaxYearsPast {
+		return StartYearTooOldError
+	}
+
+	return nil
+}
+
+// validateEndYear checks the year is after the start year, and not in the future unless allowed
+func (p ValidationPolicy) validateEndYear(startYear, year int) error {
+	if year < startYear {
+		return EndYearBeforeStartError
+	}
+
+	if !p.AllowFutureEndYears && year > p.currentYear() {
+		return EndYearTooNewError
+	}
+
+	return nil
+}
+
+// validateYears checks the first year the same way as a start year, and the last one as an end year
+func (p ValidationPolicy) validateYears(years YearSet) error {
+	if err := years.Validate(); err != nil {
+		return err
+	}
+
+	if err := p.validateStartYear(years[0].Start); err != nil {
+		return err
+	}
+
+	last := years[len(years)-1]
+	if last.End == 0 {
+		return nil
+	}
+
+	return p.validateEndYear(last.Start, last.End)
+}
+
+// validateHolder checks the holder is non-empty and not longer than MaxNameLength
+func (p ValidationPolicy) validateHolder(holder string) error {
+	if len(holder) == 0 {
+		return EmptyHolderError
+	}
+
+	if len(holder) > p.MaxNameLength {
+		return HolderTooLongError
+	}
+
+	return nil
+}
+
+// validateProjectName checks the name is non-empty and not longer than MaxNameLength
+func (p ValidationPolicy) validateProjectName(name string) error {
+	if len(name) == 0 {
+		return NameTooShortError
+	}
+
+	if len(name) > p.MaxNameLength {
+		return NameTooLongError
+	}
+
+	return nil
+}
+
+// validateCopyright checks a copyright the same way NewCopyright and the Set* methods do,
+// except for end years in the past so copyrights of earlier years can still be added.
+func (p ValidationPolicy) validateCopyright(copyright Copyright) error {
+	if err := p.validateHolder(copyright.Holder); err != nil {
+		return err
+	}
+
+	return p.validateYears(copyright.Years())
+}
+
+// SetHolder updates the holder name of the copyright, validated against MaxNameLength.
+func (p ValidationPolicy) SetHolder(c *Copyright, holder string) error {
+	if err := p.validateHolder(holder); err != nil {
+		return err
+	}
+
+	c.Holder = holder
+
+	return nil
+}
+
+// NewCopyright creates a new Copyright with the given holder name and year range.
+// The startYear must be within the last MaxYearsPast years and not in the future.
+// If endYear is 0, only startYear is set. Otherwise, endYear must be after startYear and not in the past,
+// nor in the future unless AllowFutureEndYears is set.
+func (p ValidationPolicy) NewCopyright(name string, startYear int, endYear int) (Copyright, error) {
+	if err := p.validateStartYear(startYear); err != nil {
+		return Copyright{}, err
+	}
+
+	strippedName := strings.TrimSpace(name)
+	if len(strippedName) == 0 {
+		return Copyright{}, EmptyNameError
+	}
+
+	if len(name) > p.MaxNameLength {
+		return Copyright{}, NameTooLongError
+	}
+
+	if endYear == 0 {
+		return Copyright{Holder: name, StartYear: startYear}, nil
+	}
+
+	if err := p.validateEndYear(startYear, endYear); err != nil {
+		return Copyright{}, err
+	}
+
+	if endYear < p.currentYear() {
+		return Copyright{}, EndYearTooOldError
+	}
+
+	return Copyright{Holder: name, StartYear: startYear, EndYear: endYear}, nil
+}
+
+// New creates a new License validated against the policy, see New.
+// The License's Set* methods validate with the policy as well.
+func (p ValidationPolicy) New(projectName string, holder string, startYear int, endYear int, licenseType LicenseType) (*License, error) {
+	license, err := p.newLicense(projectName, holder, startYear, endYear, licenseType)
+	if err != nil {
+		return license, err
+	}
+
+	license.policy = &p
+
+	return license, nil
+}
+
+// newLicense creates a new License validated against the policy, without keeping the policy on it
+func (p ValidationPolicy) newLicense(projectName string, holder string, startYear int, endYear int, licenseType LicenseType) (*License, error) {
+	projectName = strings.TrimSpace(projectName)
+
+	if err := p.validateProjectName(projectName); err != nil {
+		return &License{}, err
+	}
+
+	copyright, err := p.NewCopyright(holder, startYear, endYear)
+	if err != nil {
+		return &License{}, err
+	}
+
+	return &License{
+		projectName: projectName,
+		copyrights:  Copyrights{copyright},
+		licenseType: licenseType,
+	}, nil
+}
+
+// NewFromExpression creates a new License covering every license in an SPDX license expression,
+// validated against the policy, see NewFromExpression.
+func (p ValidationPolicy) NewFromExpression(projectName string, holder string, startYear int, endYear int, expression Expression) (*License, error) {
+	if err := expression.Validate(); err != nil {
+		return &License{}, err
+	}
+
+	license, err := p.New(projectName, holder, startYear, endYear, expression.LicenseTypes()[0])
+	if err != nil {
+		return &License{}, err
+	}
+
+	if err := license.SetExpression(expression); err != nil {
+		return &License{}, err
+	}
+
+	return license, nil
+}
@@ -0,0 +1,179 @@
+package ligen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidationPolicyNewCopyright(t *testing.T) {
+	longerPast := testPolicy
+	longerPast.MaxYearsPast = 100
+
+	noFutureEnd := testPolicy
+	noFutureEnd.AllowFutureEndYears = false
+
+	shortNames := testPolicy
+	shortNames.MaxNameLength = 8
+
+	tests := []struct {
+		name         string
+		policy       ValidationPolicy
+		holder       string
+		startYear    int
+		endYear      int
+		errorMessage string
+	}{
+		{name: "Pass-Default", policy: testPolicy, holder: "Max Moon", startYear: 2020, endYear: 2030},
+		{name: "Pass-MaxYearsPast", policy: longerPast, holder: "Max Moon", startYear: 1974},
+		{name: "Fail-MaxYearsPast", policy: testPolicy, holder: "Max Moon", startYear: 1974, errorMessage: StartYearTooOldError.Error()},
+		{name: "Fail-StartYearAfterClock", policy: testPolicy, holder: "Max Moon", startYear: 2026, errorMessage: StartYearTooNewError.Error()},
+		{name: "Fail-EndYearBeforeClock", policy: testPolicy, holder: "Max Moon", startYear: 2020, endYear: 2024, errorMessage: EndYearTooOldError.Error()},
+		{name: "Pass-EndYearOnClock", policy: noFutureEnd, holder: "Max Moon", startYear: 2020, endYear: 2025},
+		{name: "Fail-FutureEndYear", policy: noFutureEnd, holder: "Max Moon", startYear: 2020, endYear: 2026, errorMessage: EndYearTooNewError.Error()},
+		{name: "Fail-MaxNameLength", policy: shortNames, holder: "Peanut Butter", startYear: 2020, errorMessage: NameTooLongError.Error()},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			copyright, err := tc.policy.NewCopyright(tc.holder, tc.startYear, tc.endYear)
+
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			if copyright.Holder != tc.holder || copyright.StartYear != tc.startYear || copyright.EndYear != tc.endYear {
+				t.Errorf("Expected %s %d-%d, got %+v", tc.holder, tc.startYear, tc.endYear, copyright)
+			}
+		})
+	}
+}
+
+func TestValidationPolicySetHolder(t *testing.T) {
+	longNames := testPolicy
+	longNames.MaxNameLength = 256
+
+	tests := []struct {
+		name         string
+		policy       ValidationPolicy
+		holder       string
+		errorMessage string
+	}{
+		{name: "Pass-MaxNameLength", policy: longNames, holder: strings.Repeat("a", 200)},
+		{name: "Fail-MaxNameLength", policy: testPolicy, holder: strings.Repeat("a", 200), errorMessage: HolderTooLongError.Error()},
+		{name: "Fail-Empty", policy: longNames, holder: "", errorMessage: EmptyHolderError.Error()},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			copyright := Copyright{Holder: "Max Moon", StartYear: 2020}
+
+			err := tc.policy.SetHolder(&copyright, tc.holder)
+
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				if copyright.Holder != "Max Moon" {
+					t.Errorf("Expected the holder to be unchanged, got %s", copyright.Holder)
+				}
+				return
+			}
+
+			if copyright.Holder != tc.holder {
+				t.Errorf("Expected %s, got %s", tc.holder, copyright.Holder)
+			}
+		})
+	}
+}
+
+func TestLicenseSetWithPolicy(t *testing.T) {
+	policy := testPolicy
+	policy.MaxYearsPast = 100
+	policy.MaxNameLength = 16
+	policy.AllowFutureEndYears = false
+
+	tests := []struct {
+		name         string
+		op           func(license *License) error
+		errorMessage string
+	}{
+		{
+			name:         "Pass-SetCopyrightStartYear",
+			op:           func(license *License) error { return license.SetCopyrightStartYear(1974) },
+			errorMessage: "",
+		},
+		{
+			name:         "Fail-SetCopyrightStartYear",
+			op:           func(license *License) error { return license.SetCopyrightStartYear(1900) },
+			errorMessage: StartYearTooOldError.Error(),
+		},
+		{
+			name:         "Fail-SetCopyrightEndYear",
+			op:           func(license *License) error { return license.SetCopyrightEndYear(2026) },
+			errorMessage: EndYearTooNewError.Error(),
+		},
+		{
+			name: "Fail-SetCopyrightYears",
+			op: func(license *License) error {
+				return license.SetCopyrightYears(YearSet{{Start: 2020}, {Start: 2024, End: 2027}})
+			},
+			errorMessage: EndYearTooNewError.Error(),
+		},
+		{
+			name:         "Fail-SetHolder",
+			op:           func(license *License) error { return license.SetHolder(strings.Repeat("a", 17)) },
+			errorMessage: HolderTooLongError.Error(),
+		},
+		{
+			name:         "Fail-SetProjectName",
+			op:           func(license *License) error { return license.SetProjectName(strings.Repeat("a", 17)) },
+			errorMessage: NameTooLongError.Error(),
+		},
+		{
+			name:         "Fail-AddCopyright",
+			op:           func(license *License) error { return license.AddCopyright(Copyright{Holder: "Jelly", StartYear: 1900}) },
+			errorMessage: StartYearTooOldError.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			license, err := policy.New("Ligen", "Max Moon", 2020, 0, MIT)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkError(tc.errorMessage, tc.op(license), t)
+		})
+	}
+}
+
+func TestServiceWithPolicy(t *testing.T) {
+	policy := testPolicy
+	policy.MaxYearsPast = 100
+
+	// GIVEN
+	repo := NewFakeRepo()
+	svc := NewServiceWithPolicy(&repo, policy)
+
+	// WHEN
+	if err := svc.Create("Ligen", "Max Moon", 1974, 0, MIT); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.UpdateStartYear("LICENSE", 1972); err != nil {
+		t.Fatal(err)
+	}
+
+	// THEN
+	years, err := svc.GetYears("LICENSE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if years.Start != 1972 {
+		t.Errorf("Expected 1972, got %d", years.Start)
+	}
+
+	err = NewServiceWithPolicy(&repo, testPolicy).UpdateStartYear("LICENSE", 1971)
+	checkError(StartYearTooOldError.Error(), err, t)
+}
@@ -0,0 +1,28 @@
+package ligen
+
+import "text/template"
+
+// Body of text for a PostgreSQL License
+const PostgresqlTemplateBody = `PostgreSQL License
+
+{{range .}}Copyright (c) {{.Years}} {{.Credit}}
+{{end}}
+Permission to use, copy, modify, and distribute this software and its
+documentation for any purpose, without fee, and without a written agreement
+is hereby granted, provided that the above copyright notice and this
+paragraph and the following two paragraphs appear in all copies.
+
+IN NO EVENT SHALL {{.Holder}} BE LIABLE TO ANY PARTY FOR DIRECT,
+INDIRECT, SPECIAL, INCIDENTAL, OR CONSEQUENTIAL DAMAGES, INCLUDING LOST
+PROFITS, ARISING OUT OF THE USE OF THIS SOFTWARE AND ITS DOCUMENTATION,
+EVEN IF {{.Holder}} HAS BEEN ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+{{.Holder}} SPECIFICALLY DISCLAIMS ANY WARRANTIES, INCLUDING, BUT NOT
+LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+PARTICULAR PURPOSE. THE SOFTWARE PROVIDED HEREUNDER IS ON AN "AS IS" BASIS,
+AND {{.Holder}} HAS NO OBLIGATIONS TO PROVIDE MAINTENANCE, SUPPORT,
+UPDATES, ENHANCEMENTS, OR MODIFICATIONS.
+`
+
+// Template for PostgreSQL license
+var PostgreSQLTemplate = template.Must(template.New("PostgreSQL").Parse(PostgresqlTemplateBody))
@@ -0,0 +1,36 @@
+package ligen
+
+import "text/template"
+
+// DefaultConfidentialityClause is a confidentiality clause organizations can use as-is
+// for their proprietary licenses.
+const DefaultConfidentialityClause = `This Software contains confidential information of the copyright holder. You may not disclose it to any third party and may only use it in accordance with the terms of your agreement with the copyright holder.`
+
+// Template for a proprietary license, the confidentiality clause and contact email
+// are optional and filled in from ProprietaryInput
+const ProprietaryTemplateBody = `{{range .Copyrights}}Copyright (c) {{.Years}} {{.Credit}}
+{{end}}All rights reserved.
+
+This software and associated documentation files (the "Software") are the
+proprietary property of the copyright holder. No part of the Software may be
+used, copied, modified, merged, published, distributed, sublicensed, or sold in
+any form or by any means without the prior written permission of the copyright
+holder.
+{{- if .ConfidentialityClause}}
+
+{{.ConfidentialityClause}}
+{{- end}}
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+COPYRIGHT HOLDER BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+{{- if .ContactEmail}}
+
+For licensing inquiries, contact {{.ContactEmail}}.
+{{- end}}
+`
+
+var ProprietaryTemplate = template.Must(template.New("Proprietary").Parse(ProprietaryTemplateBody))
@@ -0,0 +1,897 @@
+package ligen
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// REUSE_TOML_PATH is where the REUSE specification keeps annotations, relative to the project root
+	REUSE_TOML_PATH = "REUSE.toml"
+	// REUSE_LICENSES_DIR is where the REUSE specification keeps license texts, relative to the project root
+	REUSE_LICENSES_DIR = "LICENSES"
+	// REUSE_TOML_VERSION is the version of the REUSE.toml format that is read and written
+	REUSE_TOML_VERSION = 1
+	// PROJECT_ANNOTATION_PATH is the path pattern of the annotation covering the whole project
+	PROJECT_ANNOTATION_PATH = "**"
+)
+
+// Precedence of an annotation over the information held in the files it covers
+const (
+	// PRECEDENCE_CLOSEST uses the information in the file when there is some, the annotation otherwise
+	PRECEDENCE_CLOSEST = "closest"
+	// PRECEDENCE_AGGREGATE uses the information in both the file and the annotation
+	PRECEDENCE_AGGREGATE = "aggregate"
+	// PRECEDENCE_OVERRIDE uses the annotation and ignores the information in the file
+	PRECEDENCE_OVERRIDE = "override"
+)
+
+var (
+	InvalidReuseTomlError            = errors.New("invalid REUSE.toml")
+	UnsupportedReuseTomlVersionError = errors.New("unsupported REUSE.toml version")
+	MissingProjectAnnotationError    = errors.New("REUSE.toml has no annotation covering the whole project")
+	EmptyAnnotationPathError         = errors.New("annotation must cover at least one path")
+	EmptyAnnotationError             = errors.New("annotation must set copyright or license information")
+	InvalidPrecedenceError           = errors.New("precedence must be one of closest, aggregate or override")
+	AnnotationNotFoundError          = errors.New("no annotation found for paths")
+)
+
+// ReuseAnnotation is one [[annotations]] table of a REUSE.toml, the licensing information
+// for the files matching its paths.
+type ReuseAnnotation struct {
+	Paths      []string
+	Precedence string
+	Copyright  []string
+	License    string
+}
+
+// Validate checks the annotation before it's written.
+// The license, when set, must be an expression of supported licenses.
+func (a ReuseAnnotation) Validate() error {
+	if len(a.Paths) == 0 {
+		return EmptyAnnotationPathError
+	}
+
+	for _, path := range a.Paths {
+		if strings.TrimSpace(path) == "" {
+			return EmptyAnnotationPathError
+		}
+	}
+
+	switch a.Precedence {
+	case "", PRECEDENCE_CLOSEST, PRECEDENCE_AGGREGATE, PRECEDENCE_OVERRIDE:
+	default:
+		return InvalidPrecedenceError
+	}
+
+	if len(a.Copyright) == 0 && a.License == "" {
+		return EmptyAnnotationError
+	}
+
+	if a.License != "" {
+		return ValidateExpression(a.License)
+	}
+
+	return nil
+}
+
+// coversProject reports whether the annotation is the one covering the whole project
+func (a ReuseAnnotation) coversProject() bool {
+	return len(a.Paths) == 1 && a.Paths[0] == PROJECT_ANNOTATION_PATH
+}
+
+// samePaths reports whether both annotations cover the same path patterns
+func (a ReuseAnnotation) samePaths(other ReuseAnnotation) bool {
+	if len(a.Paths) != len(other.Paths) {
+		return false
+	}
+
+	for idx := range a.Paths {
+		if a.Paths[idx] != other.Paths[idx] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParseReuseToml reads the annotations out of a REUSE.toml document.
+// Only the parts of TOML used by REUSE.toml are understood, unknown keys and tables are skipped.
+func ParseReuseToml(document string) ([]ReuseAnnotation, error) {
+	var annotations []ReuseAnnotation
+	var current *ReuseAnnotation
+	inAnnotation, inUnknownTable := false, false
+	version := 0
+
+	lines := strings.Split(strings.ReplaceAll(document, "\r\n", "\n"), "\n")
+	for idx := 0; idx < len(lines); idx++ {
+		lineNumber := idx + 1
+		line := strings.TrimSpace(stripTomlComment(lines[idx]))
+
+		if line == "" {
+			continue
+		}
+
+		if line == "[[annotations]]" {
+			annotations = append(annotations, ReuseAnnotation{})
+			current = &annotations[len(annotations)-1]
+			inAnnotation, inUnknownTable = true, false
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			current = nil
+			inAnnotation, inUnknownTable = false, true
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: expected key = value on line %d", InvalidReuseTomlError, lineNumber)
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"`)
+		value = strings.TrimSpace(value)
+
+		// Arrays can span several lines
+		for strings.HasPrefix(value, "[") && !tomlArrayClosed(value) {
+			idx++
+			if idx >= len(lines) {
+				return nil, fmt.Errorf("%w: unterminated array on line %d", InvalidReuseTomlError, lineNumber)
+			}
+			value += " " + strings.TrimSpace(stripTomlComment(lines[idx]))
+		}
+
+		if inUnknownTable {
+			continue
+		}
+
+		if !inAnnotation {
+			if key == "version" {
+				parsed, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fmt.Errorf("%w: version must be a number on line %d", InvalidReuseTomlError, lineNumber)
+				}
+				version = parsed
+			}
+			continue
+		}
+
+		var err error
+		switch key {
+		case "path":
+			current.Paths, err = parseTomlStrings(value)
+		case "precedence":
+			current.Precedence, err = parseTomlString(value)
+		case "SPDX-FileCopyrightText":
+			current.Copyright, err = parseTomlStrings(value)
+		case "SPDX-License-Identifier":
+			current.License, err = parseTomlString(value)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s on line %d", InvalidReuseTomlError, err.Error(), lineNumber)
+		}
+	}
+
+	if version != REUSE_TOML_VERSION {
+		return nil, UnsupportedReuseTomlVersionError
+	}
+
+	return annotations, nil
+}
+
+// EncodeReuseToml writes annotations as a REUSE.toml document.
+func EncodeReuseToml(annotations []ReuseAnnotation) string {
+	var builder strings.Builder
+
+	builder.WriteString("version = " + strconv.Itoa(REUSE_TOML_VERSION) + "\n")
+
+	for _, annotation := range annotations {
+		builder.WriteString("\n[[annotations]]\n")
+		builder.WriteString("path = " + encodeTomlStrings(annotation.Paths) + "\n")
+
+		if annotation.Precedence != "" {
+			builder.WriteString("precedence = " + quoteTomlString(annotation.Precedence) + "\n")
+		}
+
+		if len(annotation.Copyright) > 0 {
+			builder.WriteString("SPDX-FileCopyrightText = " + encodeTomlStrings(annotation.Copyright) + "\n")
+		}
+
+		if annotation.License != "" {
+			builder.WriteString("SPDX-License-Identifier = " + quoteTomlString(annotation.License) + "\n")
+		}
+	}
+
+	return builder.String()
+}
+
+// reuseTomlSection is a run of lines of a REUSE.toml document: what comes before the first table,
+// an [[annotations]] table, or a table REUSE doesn't define. The comments and blank lines right above
+// a table belong to it.
+type reuseTomlSection struct {
+	annotation bool
+	lines      []string
+}
+
+// splitReuseToml splits a document into its sections
+func splitReuseToml(document string) []reuseTomlSection {
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(document, "\r\n", "\n"), "\n"), "\n")
+	sections := []reuseTomlSection{{}}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(stripTomlComment(line))
+		if strings.HasPrefix(trimmed, "[") && !strings.Contains(trimmed, "=") {
+			current := &sections[len(sections)-1]
+
+			// Comments and blank lines above the table move along with it
+			split := len(current.lines)
+			for split > 0 && strings.TrimSpace(stripTomlComment(current.lines[split-1])) == "" {
+				split--
+			}
+
+			above := slices.Clone(current.lines[split:])
+			current.lines = current.lines[:split]
+
+			sections = append(sections, reuseTomlSection{annotation: trimmed == "[[annotations]]", lines: above})
+		}
+
+		current := &sections[len(sections)-1]
+		current.lines = append(current.lines, line)
+	}
+
+	return sections
+}
+
+// reuseAnnotationKeys returns the lines of the keys of an annotation, leaving out the ones not set
+func reuseAnnotationKeys(annotation ReuseAnnotation) map[string]string {
+	keys := map[string]string{"path": "path = " + encodeTomlStrings(annotation.Paths)}
+
+	if annotation.Precedence != "" {
+		keys["precedence"] = "precedence = " + quoteTomlString(annotation.Precedence)
+	}
+
+	if len(annotation.Copyright) > 0 {
+		keys["SPDX-FileCopyrightText"] = "SPDX-FileCopyrightText = " + encodeTomlStrings(annotation.Copyright)
+	}
+
+	if annotation.License != "" {
+		keys["SPDX-License-Identifier"] = "SPDX-License-Identifier = " + quoteTomlString(annotation.License)
+	}
+
+	return keys
+}
+
+// reuseAnnotationKeyOrder is the order the keys of an annotation are written in
+var reuseAnnotationKeyOrder = []string{"path", "precedence", "SPDX-FileCopyrightText", "SPDX-License-Identifier"}
+
+// updateReuseAnnotation rewrites the keys REUSE defines in the lines of an [[annotations]] table to
+// those of the annotation. Comments and other keys are kept, keys the table didn't have yet are added after its last key.
+func updateReuseAnnotation(lines []string, annotation ReuseAnnotation) []string {
+	keys := reuseAnnotationKeys(annotation)
+	written := make(map[string]bool)
+
+	var updated []string
+	insertAt := -1
+
+	for idx := 0; idx < len(lines); idx++ {
+		line := lines[idx]
+		stripped := strings.TrimSpace(stripTomlComment(line))
+
+		if stripped == "[[annotations]]" {
+			updated = append(updated, line)
+			insertAt = len(updated)
+			continue
+		}
+
+		key, value, ok := strings.Cut(stripped, "=")
+		if !ok {
+			updated = append(updated, line)
+			continue
+		}
+
+		// Arrays can span several lines
+		start := idx
+		value = strings.TrimSpace(value)
+		for strings.HasPrefix(value, "[") && !tomlArrayClosed(value) && idx+1 < len(lines) {
+			idx++
+			value += " " + strings.TrimSpace(stripTomlComment(lines[idx]))
+		}
+
+		key = strings.Trim(strings.TrimSpace(key), `"`)
+		if !slices.Contains(reuseAnnotationKeyOrder, key) {
+			updated = append(updated, lines[start:idx+1]...)
+			insertAt = len(updated)
+			continue
+		}
+
+		replacement, ok := keys[key]
+		if !ok || written[key] {
+			continue
+		}
+
+		// A comment trailing the key is kept when the key took up a single line
+		if comment := line[len(stripTomlComment(line)):]; comment != "" && start == idx {
+			replacement += " " + comment
+		}
+
+		updated = append(updated, replacement)
+		written[key] = true
+		insertAt = len(updated)
+	}
+
+	var missing []string
+	for _, key := range reuseAnnotationKeyOrder {
+		if replacement, ok := keys[key]; ok && !written[key] {
+			missing = append(missing, replacement)
+		}
+	}
+
+	return slices.Insert(updated, insertAt, missing...)
+}
+
+// newReuseAnnotationSection writes an annotation as a table of its own
+func newReuseAnnotationSection(annotation ReuseAnnotation) reuseTomlSection {
+	return reuseTomlSection{annotation: true, lines: updateReuseAnnotation([]string{"", "[[annotations]]"}, annotation)}
+}
+
+// UpdateReuseToml writes annotations into an existing REUSE.toml document, keeping what the annotations
+// don't change as it was written: comments, keys and tables REUSE doesn't define, and the order of the tables.
+// Annotations replace the table covering the same paths, tables none of the annotations cover are removed,
+// and annotations without a table are added after the annotation before them.
+// Returns an error if the document can't be parsed.
+func UpdateReuseToml(document string, annotations []ReuseAnnotation) (string, error) {
+	existing, err := ParseReuseToml(document)
+	if err != nil {
+		return "", err
+	}
+
+	// The section each annotation replaces, sections are numbered in the order of the existing annotations
+	replaces := make([]int, len(annotations))
+	used := make([]bool, len(existing))
+	for idx, annotation := range annotations {
+		replaces[idx] = -1
+
+		for existingIdx, other := range existing {
+			if !used[existingIdx] && other.samePaths(annotation) {
+				replaces[idx], used[existingIdx] = existingIdx, true
+				break
+			}
+		}
+	}
+
+	// New annotations follow the one before them, or lead the annotations when they come first
+	following := make(map[int][]reuseTomlSection)
+	var leading []reuseTomlSection
+	previous := -1
+	for idx, annotation := range annotations {
+		if replaces[idx] != -1 {
+			previous = replaces[idx]
+			continue
+		}
+
+		if previous == -1 {
+			leading = append(leading, newReuseAnnotationSection(annotation))
+		} else {
+			following[previous] = append(following[previous], newReuseAnnotationSection(annotation))
+		}
+	}
+
+	var sections []reuseTomlSection
+	annotationIdx := 0
+	for _, section := range splitReuseToml(document) {
+		if !section.annotation {
+			sections = append(sections, section)
+			continue
+		}
+
+		if leading != nil {
+			sections = append(sections, leading...)
+			leading = nil
+		}
+
+		if idx := slices.Index(replaces, annotationIdx); idx != -1 {
+			section.lines = updateReuseAnnotation(section.lines, annotations[idx])
+			sections = append(sections, section)
+		}
+
+		sections = append(sections, following[annotationIdx]...)
+		annotationIdx++
+	}
+
+	// Documents without annotations yet
+	sections = append(sections, leading...)
+
+	var builder strings.Builder
+	for _, section := range sections {
+		for _, line := range section.lines {
+			builder.WriteString(line + "\n")
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// stripTomlComment drops a trailing # comment, leaving any # inside strings alone
+func stripTomlComment(line string) string {
+	var quote rune
+	escaped := false
+
+	for idx, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:idx]
+		}
+	}
+
+	return line
+}
+
+// tomlArrayClosed reports whether the brackets of an array value are balanced, ignoring the ones inside strings
+func tomlArrayClosed(value string) bool {
+	depth := 0
+	var quote rune
+	escaped := false
+
+	for _, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		}
+	}
+
+	return depth == 0
+}
+
+// parseTomlStrings parses a string or an array of strings
+func parseTomlStrings(value string) ([]string, error) {
+	if !strings.HasPrefix(value, "[") {
+		single, err := parseTomlString(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{single}, nil
+	}
+
+	rest := strings.TrimSpace(value[1:])
+	var values []string
+
+	for {
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, ","))
+		if strings.HasPrefix(rest, "]") {
+			if strings.TrimSpace(rest[1:]) != "" {
+				return nil, errors.New("unexpected content after array")
+			}
+
+			return values, nil
+		}
+
+		if rest == "" {
+			return nil, errors.New("unterminated array")
+		}
+
+		parsed, remaining, err := readTomlString(rest)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, parsed)
+		rest = strings.TrimSpace(remaining)
+
+		if !strings.HasPrefix(rest, ",") && !strings.HasPrefix(rest, "]") {
+			return nil, errors.New("expected , or ] in array")
+		}
+	}
+}
+
+// parseTomlString parses a single basic or literal string
+func parseTomlString(value string) (string, error) {
+	parsed, rest, err := readTomlString(value)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSpace(rest) != "" {
+		return "", errors.New("unexpected content after string")
+	}
+
+	return parsed, nil
+}
+
+// readTomlString reads the string at the start of value and returns what follows it
+func readTomlString(value string) (string, string, error) {
+	if strings.HasPrefix(value, "'") {
+		end := strings.Index(value[1:], "'")
+		if end < 0 {
+			return "", "", errors.New("unterminated string")
+		}
+
+		return value[1 : end+1], value[end+2:], nil
+	}
+
+	if !strings.HasPrefix(value, `"`) {
+		return "", "", errors.New("expected a string")
+	}
+
+	var builder strings.Builder
+	for idx := 1; idx < len(value); idx++ {
+		c := value[idx]
+
+		if c == '"' {
+			return builder.String(), value[idx+1:], nil
+		}
+
+		if c != '\\' {
+			builder.WriteByte(c)
+			continue
+		}
+
+		idx++
+		if idx >= len(value) {
+			break
+		}
+
+		switch value[idx] {
+		case '"', '\\':
+			builder.WriteByte(value[idx])
+		case 'b':
+			builder.WriteByte('\b')
+		case 't':
+			builder.WriteByte('\t')
+		case 'n':
+			builder.WriteByte('\n')
+		case 'f':
+			builder.WriteByte('\f')
+		case 'r':
+			builder.WriteByte('\r')
+		case 'u', 'U':
+			size := 4
+			if value[idx] == 'U' {
+				size = 8
+			}
+
+			if idx+size >= len(value) {
+				return "", "", errors.New("invalid unicode escape")
+			}
+
+			code, err := strconv.ParseUint(value[idx+1:idx+1+size], 16, 32)
+			if err != nil {
+				return "", "", errors.New("invalid unicode escape")
+			}
+
+			builder.WriteRune(rune(code))
+			idx += size
+		default:
+			return "", "", fmt.Errorf("invalid escape \\%c", value[idx])
+		}
+	}
+
+	return "", "", errors.New("unterminated string")
+}
+
+// quoteTomlString writes value as a TOML basic string
+func quoteTomlString(value string) string {
+	var builder strings.Builder
+
+	builder.WriteByte('"')
+	for _, r := range value {
+		switch {
+		case r == '"' || r == '\\':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case r == '\n':
+			builder.WriteString(`\n`)
+		case r == '\t':
+			builder.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			builder.WriteString(fmt.Sprintf(`\u%04X`, r))
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	builder.WriteByte('"')
+
+	return builder.String()
+}
+
+// encodeTomlStrings writes a single value as a string and several as an array
+func encodeTomlStrings(values []string) string {
+	if len(values) == 1 {
+		return quoteTomlString(values[0])
+	}
+
+	quoted := make([]string, len(values))
+	for idx, value := range values {
+		quoted[idx] = quoteTomlString(value)
+	}
+
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// copyrightText formats a copyright the way REUSE expects in SPDX-FileCopyrightText, e.g. "2024-2025 Max Moon"
+func copyrightText(cr Copyright) string {
+	return cr.Years().String() + " " + cr.Credit()
+}
+
+// ParseCopyrightText parses the value of an SPDX-FileCopyrightText tag.
+// The "Copyright", "(c)" and "©" prefixes are optional.
+func ParseCopyrightText(text string) (Copyright, error) {
+	text = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), "SPDX-FileCopyrightText:"))
+
+	for _, prefix := range []string{"Copyright", "©", "(c)", "(C)"} {
+		text = strings.TrimSpace(strings.TrimPrefix(text, prefix))
+	}
+
+	return ParseCopyright("Copyright " + text)
+}
+
+// ReuseRepository stores licenses in the layout of the REUSE specification: each license text in
+// LICENSES/<SPDX-ID>.txt and the copyright and license of the project in the REUSE.toml annotation
+// covering every file. Paths are relative to the project root.
+type ReuseRepository struct {
+	root string
+	// tomlPath is the REUSE.toml the license was last loaded from. It's shared between copies
+	// of the repository, so Write puts the annotation back where Load found it.
+	tomlPath *string
+}
+
+// NewReuseRepository creates a new ReuseRepository for the project at root.
+// Licenses are written to the REUSE.toml at the project root until one is loaded from elsewhere.
+func NewReuseRepository(root string) ReuseRepository {
+	tomlPath := REUSE_TOML_PATH
+	return ReuseRepository{root: root, tomlPath: &tomlPath}
+}
+
+// reuseTomlPath returns the REUSE.toml Write updates
+func (r ReuseRepository) reuseTomlPath() string {
+	if r.tomlPath == nil {
+		return REUSE_TOML_PATH
+	}
+
+	return *r.tomlPath
+}
+
+// licenseTextPath returns where the text of a license is kept
+func (r ReuseRepository) licenseTextPath(id string) string {
+	return filepath.Join(r.root, REUSE_LICENSES_DIR, id+".txt")
+}
+
+// tomlStringLine returns the 1-based line of the document the TOML string value is on, 0 if it can't be found
+func tomlStringLine(document string, value string) int {
+	for idx, line := range strings.Split(strings.ReplaceAll(document, "\r\n", "\n"), "\n") {
+		if strings.Contains(line, value) {
+			return idx + 1
+		}
+	}
+
+	return 0
+}
+
+// Load reads the REUSE.toml at path and populates the License from the annotation covering the whole
+// project and the license texts it references. If a license requires a NOTICE, it's read from the project root.
+// Copyright texts that can't be parsed, like a holder without years, are kept as unparsed copyrights
+// and written back as they are. Write updates the REUSE.toml at path from then on.
+func (r ReuseRepository) Load(path string, license *License) error {
+	content, err := os.ReadFile(filepath.Join(r.root, path))
+	if err != nil {
+		return err
+	}
+
+	annotations, err := ParseReuseToml(string(content))
+	if err != nil {
+		return err
+	}
+
+	var project *ReuseAnnotation
+	for idx := range annotations {
+		if annotations[idx].coversProject() {
+			project = &annotations[idx]
+		}
+	}
+
+	if project == nil || project.License == "" {
+		return MissingProjectAnnotationError
+	}
+
+	expression, err := ParseExpression(project.License)
+	if err != nil {
+		return err
+	}
+
+	var loaded License
+	for _, text := range project.Copyright {
+		copyright, err := ParseCopyrightText(text)
+		if err != nil {
+			loaded.unparsedCopyrights = append(loaded.unparsedCopyrights, UnparsedCopyright{
+				Line: tomlStringLine(string(content), text),
+				Text: text,
+				Err:  err,
+			})
+			continue
+		}
+
+		loaded.copyrights = append(loaded.copyrights, copyright)
+	}
+
+	var notice string
+	for _, licenseType := range expression.LicenseTypes() {
+		if !licenseType.RequiresNotice() {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(r.root, "NOTICE"))
+		if err != nil {
+			return err
+		}
+		notice = string(content)
+
+		loaded.projectName, err = ParseProjectNameFromNotice(notice)
+		if err != nil {
+			return err
+		}
+
+		break
+	}
+
+	for _, licenseType := range expression.LicenseTypes() {
+		content, err := os.ReadFile(r.licenseTextPath(licenseType.SPDXID()))
+		if err != nil {
+			return err
+		}
+
+		parameters, err := parseParameters(licenseType, string(content), notice)
+		if err != nil {
+			return err
+		}
+
+		loaded.parameters = mergeParameters(loaded.parameters, parameters)
+	}
+
+	if err := loaded.SetExpression(expression); err != nil {
+		return err
+	}
+
+	*license = loaded
+	if r.tomlPath != nil {
+		*r.tomlPath = path
+	}
+
+	return nil
+}
+
+// Write writes the text of each license to LICENSES/, the NOTICE to the project root if a license
+// requires one, and updates the annotation covering the whole project in the REUSE.toml the license
+// was loaded from, the one at the project root otherwise.
+// The text of a license another one incorporates, like the GPL for the GNU Lesser license, is written
+// to its own file and listed with an annotation. Other annotations are kept.
+func (r ReuseRepository) Write(license *License) error {
+	rendered, err := license.renderEach()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Join(r.root, REUSE_LICENSES_DIR), 0755); err != nil {
+		return err
+	}
+
+	for _, current := range rendered {
+		if err := os.WriteFile(r.licenseTextPath(current.licenseType.SPDXID()), []byte(current.text), 0644); err != nil {
+			return err
+		}
+	}
+
+	if notice, ok := license.mergedNotice(rendered); ok {
+		if err := os.WriteFile(filepath.Join(r.root, notice.Path), []byte(notice.Content), 0644); err != nil {
+			return err
+		}
+	}
+
+	annotations, err := r.LoadAnnotations(r.reuseTomlPath())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	project := ReuseAnnotation{
+		Paths:   []string{PROJECT_ANNOTATION_PATH},
+		License: license.licenseExpression().String(),
+	}
+	for _, copyright := range license.copyrights {
+		if copyright.Holder != "" {
+			project.Copyright = append(project.Copyright, copyrightText(copyright))
+		}
+	}
+	for _, unparsed := range license.unparsedCopyrights {
+		project.Copyright = append(project.Copyright, unparsed.Text)
+	}
+
+	replaced := false
+	for idx := range annotations {
+		if annotations[idx].coversProject() {
+			project.Precedence = annotations[idx].Precedence
+			annotations[idx] = project
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		annotations = append([]ReuseAnnotation{project}, annotations...)
+	}
+
+	// The text of a license another one incorporates gets an annotation of its own after the
+	// project's, so it's listed as in use even though no file is licensed under it
+	for _, current := range rendered {
+		if !current.incorporated {
+			continue
+		}
+
+		id := current.licenseType.SPDXID()
+		listed := ReuseAnnotation{Paths: []string{REUSE_LICENSES_DIR + "/" + id + ".txt"}, License: id}
+		if slices.ContainsFunc(annotations, listed.samePaths) {
+			continue
+		}
+
+		annotations = slices.Insert(annotations, slices.IndexFunc(annotations, ReuseAnnotation.coversProject)+1, listed)
+	}
+
+	return r.WriteAnnotations(r.reuseTomlPath(), annotations)
+}
+
+// LoadAnnotations reads the annotations of the REUSE.toml at path.
+func (r ReuseRepository) LoadAnnotations(path string) ([]ReuseAnnotation, error) {
+	content, err := os.ReadFile(filepath.Join(r.root, path))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseReuseToml(string(content))
+}
+
+// WriteAnnotations writes the given annotations to the REUSE.toml at path. An existing file is updated
+// with UpdateReuseToml so its comments and the keys and tables REUSE doesn't define are kept.
+func (r ReuseRepository) WriteAnnotations(path string, annotations []ReuseAnnotation) error {
+	full := filepath.Join(r.root, path)
+
+	content, err := os.ReadFile(full)
+	if errors.Is(err, os.ErrNotExist) {
+		return os.WriteFile(full, []byte(EncodeReuseToml(annotations)), 0644)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	updated, err := UpdateReuseToml(string(content), annotations)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(full, []byte(updated), 0644)
+}
@@ -0,0 +1,297 @@
+package ligen
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ReuseLintReport lists the problems found when checking a project against the REUSE specification.
+// Each list is sorted, paths are relative to the project root and use forward slashes.
+type ReuseLintReport struct {
+	// MissingLicenses are licenses and exceptions in use without a text in LICENSES/
+	MissingLicenses []string
+	// UnusedLicenses are texts in LICENSES/ no file uses
+	UnusedLicenses []string
+	// MissingLicensing are files without license information
+	MissingLicensing []string
+	// MissingCopyright are files without copyright information
+	MissingCopyright []string
+}
+
+// Compliant reports whether the project passed every check.
+func (r ReuseLintReport) Compliant() bool {
+	return len(r.MissingLicenses) == 0 &&
+		len(r.UnusedLicenses) == 0 &&
+		len(r.MissingLicensing) == 0 &&
+		len(r.MissingCopyright) == 0
+}
+
+var (
+	licenseTagPattern   = regexp.MustCompile(`SPDX-License-Identifier:\s*(.+)$`)
+	copyrightTagPattern = regexp.MustCompile(`(?:SPDX-(?:File|Snippet)CopyrightText:|Copyright|©)\s*\S`)
+	// Files REUSE doesn't require licensing information for
+	reuseIgnoredFilePattern = regexp.MustCompile(`^(?:LICEN[CS]E|COPYING)(?:[-.].*)?$`)
+)
+
+var reuseIgnoredDirs = map[string]bool{
+	".git":             true,
+	".hg":              true,
+	".sl":              true,
+	".svn":             true,
+	".reuse":           true,
+	REUSE_LICENSES_DIR: true,
+}
+
+// commentClosers are stripped from the end of license tags written in block comments
+var commentClosers = []string{"*/", "-->", "*)", "#}", "%}", "]]"}
+
+// licensingInfo is the copyright and license information that applies to a file
+type licensingInfo struct {
+	copyright bool
+	licenses  []string
+}
+
+// fileLicensingInfo reads the tags out of a file's content
+func fileLicensingInfo(content []byte) licensingInfo {
+	var info licensingInfo
+
+	// Binary files can only be covered by a .license file or an annotation
+	if bytes.IndexByte(content, 0) >= 0 {
+		return info
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if matches := licenseTagPattern.FindStringSubmatch(line); matches != nil {
+			expression := strings.TrimSpace(matches[1])
+			for _, closer := range commentClosers {
+				expression = strings.TrimSpace(strings.TrimSuffix(expression, closer))
+			}
+
+			if expression != "" {
+				info.licenses = append(info.licenses, expression)
+			}
+		}
+
+		if copyrightTagPattern.MatchString(line) {
+			info.copyright = true
+		}
+	}
+
+	return info
+}
+
+// expressionIdentifiers returns the license and exception identifiers used in an expression, without
+// requiring ligen to support them. A trailing "+" is dropped, its text is that of the license itself.
+func expressionIdentifiers(expression string) []string {
+	var ids []string
+
+	for _, token := range tokenizeExpression(expression) {
+		if token.value == "(" || token.value == ")" || isExpressionKeyword(token.value) {
+			continue
+		}
+
+		ids = append(ids, strings.TrimSuffix(token.value, "+"))
+	}
+
+	return ids
+}
+
+// reuseGlobPattern converts a REUSE.toml path pattern to a regular expression.
+// "*" matches within a directory, "**" across directories and "\*" a literal asterisk.
+func reuseGlobPattern(glob string) *regexp.Regexp {
+	var builder strings.Builder
+	builder.WriteString("^")
+
+	for idx := 0; idx < len(glob); idx++ {
+		switch {
+		case strings.HasPrefix(glob[idx:], `\*`):
+			builder.WriteString(`\*`)
+			idx++
+		case strings.HasPrefix(glob[idx:], "**"):
+			builder.WriteString(".*")
+			idx++
+		case glob[idx] == '*':
+			builder.WriteString("[^/]*")
+		default:
+			builder.WriteString(regexp.QuoteMeta(glob[idx : idx+1]))
+		}
+	}
+
+	builder.WriteString("$")
+
+	return regexp.MustCompile(builder.String())
+}
+
+// annotationFor returns the last annotation covering path, the one REUSE applies
+func annotationFor(path string, annotations []ReuseAnnotation, patterns [][]*regexp.Regexp) (ReuseAnnotation, bool) {
+	for idx := len(annotations) - 1; idx >= 0; idx-- {
+		for _, pattern := range patterns[idx] {
+			if pattern.MatchString(path) {
+				return annotations[idx], true
+			}
+		}
+	}
+
+	return ReuseAnnotation{}, false
+}
+
+// applyAnnotation combines the information in a file with the annotation covering it, following its precedence
+func applyAnnotation(own licensingInfo, annotation ReuseAnnotation) licensingInfo {
+	fromAnnotation := licensingInfo{copyright: len(annotation.Copyright) > 0}
+	if annotation.License != "" {
+		fromAnnotation.licenses = []string{annotation.License}
+	}
+
+	switch annotation.Precedence {
+	case PRECEDENCE_OVERRIDE:
+		return fromAnnotation
+	case PRECEDENCE_AGGREGATE:
+		return licensingInfo{
+			copyright: own.copyright || fromAnnotation.copyright,
+			licenses:  append(own.licenses, fromAnnotation.licenses...),
+		}
+	default:
+		if !own.copyright {
+			own.copyright = fromAnnotation.copyright
+		}
+
+		if len(own.licenses) == 0 {
+			own.licenses = fromAnnotation.licenses
+		}
+
+		return own
+	}
+}
+
+// Lint checks the project against the REUSE specification, the same way "reuse lint" does:
+// every file must have copyright and license information, from its own tags, a .license file next to
+// it or an annotation in REUSE.toml, and LICENSES/ must hold exactly the licenses in use or listed
+// with an annotation covering their text.
+func (r ReuseRepository) Lint() (ReuseLintReport, error) {
+	annotations, err := r.LoadAnnotations(REUSE_TOML_PATH)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return ReuseLintReport{}, err
+	}
+
+	patterns := make([][]*regexp.Regexp, len(annotations))
+	for idx, annotation := range annotations {
+		for _, path := range annotation.Paths {
+			patterns[idx] = append(patterns[idx], reuseGlobPattern(path))
+		}
+	}
+
+	var report ReuseLintReport
+	used := make(map[string]bool)
+
+	err = filepath.WalkDir(r.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relative, err := filepath.Rel(r.root, path)
+		if err != nil {
+			return err
+		}
+		relative = filepath.ToSlash(relative)
+
+		if entry.IsDir() {
+			if relative != "." && reuseIgnoredDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		name := entry.Name()
+		if relative == REUSE_TOML_PATH || strings.HasSuffix(name, ".license") || reuseIgnoredFilePattern.MatchString(name) || !entry.Type().IsRegular() {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		// Empty files don't need licensing information
+		if len(content) == 0 {
+			return nil
+		}
+
+		// A .license file next to a file replaces the information in it
+		if sidecar, err := os.ReadFile(path + ".license"); err == nil {
+			content = sidecar
+		}
+
+		info := fileLicensingInfo(content)
+		if annotation, ok := annotationFor(relative, annotations, patterns); ok {
+			info = applyAnnotation(info, annotation)
+		}
+
+		if !info.copyright {
+			report.MissingCopyright = append(report.MissingCopyright, relative)
+		}
+
+		if len(info.licenses) == 0 {
+			report.MissingLicensing = append(report.MissingLicensing, relative)
+		}
+
+		for _, expression := range info.licenses {
+			for _, id := range expressionIdentifiers(expression) {
+				used[id] = true
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return ReuseLintReport{}, err
+	}
+
+	available := make(map[string]bool)
+	entries, err := os.ReadDir(filepath.Join(r.root, REUSE_LICENSES_DIR))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return ReuseLintReport{}, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		// A license text can be listed with an annotation of its own, like the GPL the GNU Lesser license incorporates
+		if annotation, ok := annotationFor(REUSE_LICENSES_DIR+"/"+entry.Name(), annotations, patterns); ok && !annotation.coversProject() {
+			for _, id := range expressionIdentifiers(annotation.License) {
+				used[id] = true
+			}
+		}
+
+		// Versions look like extensions, "Apache-2.0" without one is still Apache-2.0
+		id := entry.Name()
+		if ext := filepath.Ext(id); strings.Trim(ext, ".0123456789") != "" {
+			id = strings.TrimSuffix(id, ext)
+		}
+		available[id] = true
+
+		if !used[id] {
+			report.UnusedLicenses = append(report.UnusedLicenses, id)
+		}
+	}
+
+	for id := range used {
+		if !available[id] {
+			report.MissingLicenses = append(report.MissingLicenses, id)
+		}
+	}
+
+	sort.Strings(report.MissingLicenses)
+	sort.Strings(report.UnusedLicenses)
+
+	return report, nil
+}
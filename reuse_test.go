@@ -0,0 +1,680 @@
+package ligen
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseReuseToml(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expected     []ReuseAnnotation
+		errorMessage string
+	}{
+		{
+			name: "Passing",
+			input: `version = 1 # the only version there is
+
+[[annotations]]
+path = "**"
+SPDX-FileCopyrightText = "2024 Peanut Butter <pb@example.com>"
+SPDX-License-Identifier = "MIT OR Apache-2.0"
+
+[[annotations]]
+path = [
+    "docs/**",  # documentation
+    'images/*.png',
+]
+precedence = "override"
+SPDX-FileCopyrightText = ["2023 Jelly", "2024 Peanut \"PB\" Butter"]
+SPDX-License-Identifier = "CC-BY-4.0"
+`,
+			expected: []ReuseAnnotation{
+				{
+					Paths:     []string{"**"},
+					Copyright: []string{"2024 Peanut Butter <pb@example.com>"},
+					License:   "MIT OR Apache-2.0",
+				},
+				{
+					Paths:      []string{"docs/**", "images/*.png"},
+					Precedence: PRECEDENCE_OVERRIDE,
+					Copyright:  []string{"2023 Jelly", `2024 Peanut "PB" Butter`},
+					License:    "CC-BY-4.0",
+				},
+			},
+			errorMessage: "",
+		},
+		{
+			name: "Passing-UnknownTableSkipped",
+			input: `version = 1
+
+[tool.ligen]
+path = "ignored"
+
+[[annotations]]
+path = "src/**"
+SPDX-License-Identifier = "MIT"
+`,
+			expected: []ReuseAnnotation{
+				{
+					Paths:   []string{"src/**"},
+					License: "MIT",
+				},
+			},
+			errorMessage: "",
+		},
+		{
+			name: "Failing-Version",
+			input: `version = 2
+`,
+			expected:     nil,
+			errorMessage: UnsupportedReuseTomlVersionError.Error(),
+		},
+		{
+			name: "Failing-UnterminatedString",
+			input: `version = 1
+
+[[annotations]]
+path = "src/**
+`,
+			expected:     nil,
+			errorMessage: "invalid REUSE.toml: unterminated string on line 4",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			annotations, err := ParseReuseToml(tc.input)
+
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			if !reflect.DeepEqual(tc.expected, annotations) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, annotations)
+			}
+
+			// What's encoded reads back the same
+			roundTrip, err := ParseReuseToml(EncodeReuseToml(annotations))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(annotations, roundTrip) {
+				t.Errorf("Expected %+v after encoding, got %+v", annotations, roundTrip)
+			}
+		})
+	}
+}
+
+func TestReuseRepositoryRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		parameters Parameters
+		// files expected in the project once written
+		expectedFiles []string
+	}{
+		{
+			name:          "Pass-MIT",
+			expression:    "MIT",
+			expectedFiles: []string{"LICENSES/MIT.txt", "REUSE.toml"},
+		},
+		{
+			name:          "Pass-MIT-Or-Apache",
+			expression:    "MIT OR Apache-2.0",
+			expectedFiles: []string{"LICENSES/Apache-2.0.txt", "LICENSES/MIT.txt", "NOTICE", "REUSE.toml"},
+		},
+		{
+			name:          "Pass-GNULesser",
+			expression:    "LGPL-3.0-or-later",
+			expectedFiles: []string{"LICENSES/GPL-3.0-or-later.txt", "LICENSES/LGPL-3.0-or-later.txt", "NOTICE", "REUSE.toml"},
+		},
+		{
+			name:       "Pass-BusinessSource",
+			expression: "BUSL-1.1",
+			parameters: Parameters{
+				Licensor:           "Peanut Butter Inc.",
+				LicensedWork:       "Ligen 1.0",
+				AdditionalUseGrant: "None",
+				ChangeDate:         time.Date(2028, 6, 1, 0, 0, 0, 0, time.UTC),
+				ChangeLicense:      "Apache License, Version 2.0",
+			},
+			expectedFiles: []string{"LICENSES/BUSL-1.1.txt", "NOTICE", "REUSE.toml"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// GIVEN
+			root := t.TempDir()
+			repo := NewReuseRepository(root)
+
+			expression, err := ParseExpression(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			license, err := NewFromExpression("Ligen", "Peanut Butter", 2024, 0, expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = license.SetParameters(tc.parameters); err != nil {
+				t.Fatal(err)
+			}
+
+			// WHEN
+			if err = repo.Write(license); err != nil {
+				t.Fatal(err)
+			}
+
+			var loaded License
+			err = repo.Load(REUSE_TOML_PATH, &loaded)
+
+			// THEN
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var files []string
+			filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+				if !entry.IsDir() {
+					relative, _ := filepath.Rel(root, path)
+					files = append(files, filepath.ToSlash(relative))
+				}
+				return nil
+			})
+
+			if !reflect.DeepEqual(tc.expectedFiles, files) {
+				t.Errorf("Expected files %v, got %v", tc.expectedFiles, files)
+			}
+
+			// Projects without a NOTICE have nowhere to keep the project name
+			if len(loaded.projectName) == 0 {
+				loaded.projectName = license.projectName
+			}
+
+			if !reflect.DeepEqual(*license, loaded) {
+				t.Errorf("Expected %+v, got %+v", *license, loaded)
+			}
+		})
+	}
+}
+
+func TestReuseRepositoryWriteKeepsAnnotations(t *testing.T) {
+	// GIVEN
+	root := t.TempDir()
+	existing := `version = 1
+
+[[annotations]]
+path = "docs/**"
+SPDX-FileCopyrightText = "2023 Jelly"
+SPDX-License-Identifier = "CC-BY-4.0"
+
+[[annotations]]
+path = "**"
+precedence = "aggregate"
+SPDX-FileCopyrightText = "2020 Someone Else"
+SPDX-License-Identifier = "ISC"
+`
+	if err := os.WriteFile(filepath.Join(root, REUSE_TOML_PATH), []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := NewReuseRepository(root)
+	license, err := New("Ligen", "Peanut Butter", 2024, 0, MIT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// WHEN
+	if err = repo.Write(license); err != nil {
+		t.Fatal(err)
+	}
+
+	// THEN
+	annotations, err := repo.LoadAnnotations(REUSE_TOML_PATH)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ReuseAnnotation{
+		{Paths: []string{"docs/**"}, Copyright: []string{"2023 Jelly"}, License: "CC-BY-4.0"},
+		{Paths: []string{"**"}, Precedence: PRECEDENCE_AGGREGATE, Copyright: []string{"2024 Peanut Butter"}, License: "MIT"},
+	}
+
+	if !reflect.DeepEqual(expected, annotations) {
+		t.Errorf("Expected %+v, got %+v", expected, annotations)
+	}
+}
+
+func TestReuseRepositoryWriteIncorporatedLicense(t *testing.T) {
+	// GIVEN
+	root := t.TempDir()
+	repo := NewReuseRepository(root)
+
+	license, err := New("Ligen", "Peanut Butter", 2024, 0, GNU_LESSER_3_0_ONLY)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// WHEN
+	if err = repo.Write(license); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writing twice doesn't list the incorporated license twice
+	if err = repo.Write(license); err != nil {
+		t.Fatal(err)
+	}
+
+	// THEN
+	for id, expected := range map[string]string{"LGPL-3.0-only": GNULesserLicenseBody, "GPL-3.0-only": GNUGeneral3LicenseBody} {
+		content, err := os.ReadFile(repo.licenseTextPath(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(content) != expected {
+			t.Errorf("Expected LICENSES/%s.txt to hold only its own license text", id)
+		}
+	}
+
+	annotations, err := repo.LoadAnnotations(REUSE_TOML_PATH)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ReuseAnnotation{
+		{Paths: []string{"**"}, Copyright: []string{"2024 Peanut Butter"}, License: "LGPL-3.0-only"},
+		{Paths: []string{"LICENSES/GPL-3.0-only.txt"}, License: "GPL-3.0-only"},
+	}
+
+	if !reflect.DeepEqual(expected, annotations) {
+		t.Errorf("Expected %+v, got %+v", expected, annotations)
+	}
+
+	report, err := repo.Lint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !report.Compliant() {
+		t.Errorf("Expected a compliant project, got %+v", report)
+	}
+}
+
+func TestReuseRepositoryLoadYearlessCopyright(t *testing.T) {
+	// GIVEN
+	root := t.TempDir()
+	repo := NewReuseRepository(root)
+
+	license, err := New("Ligen", "Peanut Butter", 2024, 0, MIT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = repo.Write(license); err != nil {
+		t.Fatal(err)
+	}
+
+	existing := `version = 1
+
+[[annotations]]
+path = "**"
+SPDX-FileCopyrightText = [
+    "2024 Peanut Butter",
+    "Acme Corp",
+]
+SPDX-License-Identifier = "MIT"
+`
+	if err := os.WriteFile(filepath.Join(root, REUSE_TOML_PATH), []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// WHEN
+	var loaded License
+	err = repo.Load(REUSE_TOML_PATH, &loaded)
+
+	// THEN
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(license.copyrights, loaded.copyrights) {
+		t.Errorf("Expected copyrights %v, got %v", license.copyrights, loaded.copyrights)
+	}
+
+	if len(loaded.unparsedCopyrights) != 1 {
+		t.Fatalf("Expected 1 unparsed copyright, got %+v", loaded.unparsedCopyrights)
+	}
+
+	unparsed := loaded.unparsedCopyrights[0]
+	if unparsed.Text != "Acme Corp" || unparsed.Line != 7 || unparsed.Err == nil {
+		t.Errorf("Expected Acme Corp on line 7 with an error, got %+v", unparsed)
+	}
+
+	// The yearless copyright is written back as it was
+	if err = repo.Write(&loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	annotations, err := repo.LoadAnnotations(REUSE_TOML_PATH)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"2024 Peanut Butter", "Acme Corp"}
+	if !reflect.DeepEqual(expected, annotations[0].Copyright) {
+		t.Errorf("Expected %v, got %v", expected, annotations[0].Copyright)
+	}
+}
+
+func TestReuseRepositoryWriteToLoadedPath(t *testing.T) {
+	// GIVEN
+	root := t.TempDir()
+	repo := NewReuseRepository(root)
+
+	license, err := New("Ligen", "Peanut Butter", 2024, 0, MIT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = repo.Write(license); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join("legal", REUSE_TOML_PATH)
+	if err := os.Mkdir(filepath.Join(root, "legal"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(filepath.Join(root, REUSE_TOML_PATH), filepath.Join(root, path)); err != nil {
+		t.Fatal(err)
+	}
+
+	var loaded License
+	if err = repo.Load(path, &loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = loaded.SetHolder("Jelly"); err != nil {
+		t.Fatal(err)
+	}
+
+	// WHEN
+	if err = repo.Write(&loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	// THEN
+	if _, err := os.Stat(filepath.Join(root, REUSE_TOML_PATH)); !os.IsNotExist(err) {
+		t.Errorf("Expected no REUSE.toml at the project root, got %v", err)
+	}
+
+	annotations, err := repo.LoadAnnotations(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ReuseAnnotation{{Paths: []string{"**"}, Copyright: []string{"2024 Jelly"}, License: "MIT"}}
+	if !reflect.DeepEqual(expected, annotations) {
+		t.Errorf("Expected %+v, got %+v", expected, annotations)
+	}
+}
+
+func TestReuseRepositoryWriteKeepsComments(t *testing.T) {
+	// GIVEN
+	root := t.TempDir()
+	existing := `# Licensing of the project, see https://reuse.software
+version = 1
+
+# The docs are shared with the website
+[[annotations]]
+path = "docs/**"
+SPDX-FileCopyrightText = "2023 Jelly"
+SPDX-License-Identifier = "CC-BY-4.0"
+
+# Everything else
+[[annotations]]
+path = "**"
+precedence = "aggregate" # files may add their own
+SPDX-FileCopyrightText = [
+    "2020 Someone Else", # the original author
+]
+SPDX-License-Identifier = "ISC"
+x-reviewed-by = "legal"
+
+[tool.ligen]
+threshold = 0.9
+`
+	if err := os.WriteFile(filepath.Join(root, REUSE_TOML_PATH), []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := NewReuseRepository(root)
+	license, err := New("Ligen", "Peanut Butter", 2024, 0, MIT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// WHEN
+	if err = repo.Write(license); err != nil {
+		t.Fatal(err)
+	}
+
+	// THEN
+	content, err := os.ReadFile(filepath.Join(root, REUSE_TOML_PATH))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# Licensing of the project, see https://reuse.software
+version = 1
+
+# The docs are shared with the website
+[[annotations]]
+path = "docs/**"
+SPDX-FileCopyrightText = "2023 Jelly"
+SPDX-License-Identifier = "CC-BY-4.0"
+
+# Everything else
+[[annotations]]
+path = "**"
+precedence = "aggregate" # files may add their own
+SPDX-FileCopyrightText = "2024 Peanut Butter"
+SPDX-License-Identifier = "MIT"
+x-reviewed-by = "legal"
+
+[tool.ligen]
+threshold = 0.9
+`
+	if string(content) != expected {
+		t.Errorf("Expected %q, got %q", expected, content)
+	}
+}
+
+func TestUpdateReuseToml(t *testing.T) {
+	document := `version = 1 # REUSE.toml format
+
+# Docs
+[[annotations]]
+path = "docs/**"
+SPDX-License-Identifier = "CC-BY-4.0"
+
+# Project
+[[annotations]]
+path = "**"
+SPDX-License-Identifier = "MIT"
+`
+
+	docs := ReuseAnnotation{Paths: []string{"docs/**"}, License: "CC-BY-4.0"}
+	project := ReuseAnnotation{Paths: []string{"**"}, License: "MIT"}
+	vendor := ReuseAnnotation{Paths: []string{"vendor/**"}, Precedence: PRECEDENCE_OVERRIDE, License: "Apache-2.0"}
+
+	tests := []struct {
+		name        string
+		document    string
+		annotations []ReuseAnnotation
+		expected    string
+	}{
+		{
+			name:        "Pass-Unchanged",
+			document:    document,
+			annotations: []ReuseAnnotation{docs, project},
+			expected:    document,
+		},
+		{
+			name:        "Pass-Added",
+			document:    document,
+			annotations: []ReuseAnnotation{docs, vendor, project},
+			expected: `version = 1 # REUSE.toml format
+
+# Docs
+[[annotations]]
+path = "docs/**"
+SPDX-License-Identifier = "CC-BY-4.0"
+
+[[annotations]]
+path = "vendor/**"
+precedence = "override"
+SPDX-License-Identifier = "Apache-2.0"
+
+# Project
+[[annotations]]
+path = "**"
+SPDX-License-Identifier = "MIT"
+`,
+		},
+		{
+			name:        "Pass-Removed",
+			document:    document,
+			annotations: []ReuseAnnotation{project},
+			expected: `version = 1 # REUSE.toml format
+
+# Project
+[[annotations]]
+path = "**"
+SPDX-License-Identifier = "MIT"
+`,
+		},
+		{
+			name:        "Pass-FirstAnnotation",
+			document:    "# Nothing annotated yet\nversion = 1\n",
+			annotations: []ReuseAnnotation{project},
+			expected:    "# Nothing annotated yet\nversion = 1\n\n[[annotations]]\npath = \"**\"\nSPDX-License-Identifier = \"MIT\"\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			updated, err := UpdateReuseToml(tc.document, tc.annotations)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if updated != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, updated)
+			}
+
+			annotations, err := ParseReuseToml(updated)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tc.annotations, annotations) {
+				t.Errorf("Expected %+v, got %+v", tc.annotations, annotations)
+			}
+		})
+	}
+
+	if _, err := UpdateReuseToml("version = 2\n", nil); err == nil {
+		t.Error("Expected an error for an unsupported document")
+	}
+}
+
+func TestReuseLint(t *testing.T) {
+	// GIVEN
+	root := t.TempDir()
+	files := map[string]string{
+		"REUSE.toml": `version = 1
+
+[[annotations]]
+path = "docs/**"
+SPDX-FileCopyrightText = "2024 Peanut Butter"
+SPDX-License-Identifier = "CC-BY-4.0"
+`,
+		"LICENSES/MIT.txt":          "MIT text",
+		"LICENSES/CC-BY-4.0.txt":    "CC BY text",
+		"LICENSES/GPL-3.0-only.txt": "GPL text",
+		"LICENSE":                   "ignored, like reuse does",
+		"main.go":                   "// SPDX-FileCopyrightText: 2024 Peanut Butter\n// SPDX-License-Identifier: MIT\npackage main\n",
+		"lib.c":                     "/* SPDX-License-Identifier: Apache-2.0 WITH LLVM-exception */\nint main() {}\n",
+		"docs/guide.md":             "# Guide\n",
+		"image.png":                 "\x89PNG\x00\x00",
+		"image.png.license":         "SPDX-FileCopyrightText: 2024 Peanut Butter\nSPDX-License-Identifier: MIT\n",
+		"notes.txt":                 "nothing to see here\n",
+		"empty.txt":                 "",
+	}
+
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// WHEN
+	report, err := NewReuseRepository(root).Lint()
+
+	// THEN
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ReuseLintReport{
+		MissingLicenses:  []string{"Apache-2.0", "LLVM-exception"},
+		UnusedLicenses:   []string{"GPL-3.0-only"},
+		MissingLicensing: []string{"notes.txt"},
+		MissingCopyright: []string{"lib.c", "notes.txt"},
+	}
+
+	if !reflect.DeepEqual(expected, report) {
+		t.Errorf("Expected %+v, got %+v", expected, report)
+	}
+
+	if report.Compliant() {
+		t.Error("Expected the project not to be compliant")
+	}
+}
+
+func TestReuseGlobPattern(t *testing.T) {
+	tests := []struct {
+		glob     string
+		path     string
+		expected bool
+	}{
+		{glob: "**", path: "src/main.go", expected: true},
+		{glob: "*.go", path: "main.go", expected: true},
+		{glob: "*.go", path: "src/main.go", expected: false},
+		{glob: "src/**/*.go", path: "src/a/b/main.go", expected: true},
+		{glob: `literal\*.txt`, path: "literal*.txt", expected: true},
+		{glob: `literal\*.txt`, path: "literally.txt", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.glob+"-"+tc.path, func(t *testing.T) {
+			if matched := reuseGlobPattern(tc.glob).MatchString(tc.path); matched != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, matched)
+			}
+		})
+	}
+}
@@ -1,24 +1,50 @@
 package ligen
 
+import (
+	"errors"
+	"time"
+)
+
+var UnsupportedRepositoryError = errors.New("repository does not support this operation")
+
 // Repository provides an abstraction for loading and writing licenses from different storage backends
 type Repository interface {
 	Load(path string, license *License) error
 	Write(license *License) error
 }
 
+// Annotator is implemented by repositories that keep licensing information for files other
+// than the license itself, like the REUSE.toml annotations of ReuseRepository.
+type Annotator interface {
+	LoadAnnotations(path string) ([]ReuseAnnotation, error)
+	WriteAnnotations(path string, annotations []ReuseAnnotation) error
+}
+
+// Linter is implemented by repositories that can check a project's licensing information.
+type Linter interface {
+	Lint() (ReuseLintReport, error)
+}
+
 // Service provides business logic operations for managing licenses.
 type Service struct {
-	repo Repository
+	repo   Repository
+	policy ValidationPolicy
 }
 
 // NewService creates a new Service with the given repository.
 func NewService(repo Repository) Service {
-	return Service{repo: repo}
+	return NewServiceWithPolicy(repo, DefaultValidationPolicy())
+}
+
+// NewServiceWithPolicy creates a new Service with the given repository, which validates
+// licenses and copyrights against the given policy.
+func NewServiceWithPolicy(repo Repository, policy ValidationPolicy) Service {
+	return Service{repo: repo, policy: policy}
 }
 
 // Create creates a new license with the given parameters and writes it via the repository.
 func (s Service) Create(projectName string, holder string, start, end int, licenseType LicenseType) error {
-	license, err := New(projectName, holder, start, end, licenseType)
+	license, err := s.policy.New(projectName, holder, start, end, licenseType)
 	if err != nil {
 		return err
 	}
@@ -30,6 +56,32 @@ func (s Service) Create(projectName string, holder string, start, end int, licen
 	return nil
 }
 
+// CreateWithParameters creates a new license that takes license specific parameters, such as the
+// Business Source License, and writes it via the repository.
+func (s Service) CreateWithParameters(projectName string, holder string, start, end int, licenseType LicenseType, parameters Parameters) error {
+	license, err := s.policy.New(projectName, holder, start, end, licenseType)
+	if err != nil {
+		return err
+	}
+
+	if err = license.SetParameters(parameters); err != nil {
+		return err
+	}
+
+	return s.repo.Write(license)
+}
+
+// CreateFromExpression creates a new license covering every license in an SPDX license expression,
+// e.g. "MIT OR Apache-2.0", and writes it via the repository.
+func (s Service) CreateFromExpression(projectName string, holder string, start, end int, expression Expression) error {
+	license, err := s.policy.NewFromExpression(projectName, holder, start, end, expression)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Write(license)
+}
+
 // CopyrightYears contains the start and end years of a copyright.
 type CopyrightYears struct {
 	Start int
@@ -39,11 +91,12 @@ type CopyrightYears struct {
 func (s Service) load(path string) (*License, error) {
 	var license License
 	err := s.repo.Load(path, &license)
+	license.SetValidationPolicy(s.policy)
 
 	return &license, err
 }
 
-// GetYears loads a license from the given path and returns its copyright years.
+// GetYears loads a license from the given path and returns the years of its primary copyright.
 func (s Service) GetYears(path string) (CopyrightYears, error) {
 	license, err := s.load(path)
 	if err != nil {
@@ -51,8 +104,8 @@ func (s Service) GetYears(path string) (CopyrightYears, error) {
 	}
 
 	return CopyrightYears{
-		Start: license.copyright.StartYear,
-		End:   license.copyright.EndYear,
+		Start: license.copyrights.StartYear(),
+		End:   license.copyrights.EndYear(),
 	}, nil
 }
 
@@ -66,6 +119,26 @@ func (s Service) GetLicenseType(path string) (LicenseType, error) {
 	return license.licenseType, nil
 }
 
+// GetExpression loads a license from the given path and returns the SPDX license expression it covers.
+func (s Service) GetExpression(path string) (Expression, error) {
+	license, err := s.load(path)
+	if err != nil {
+		return Expression{}, err
+	}
+
+	return license.licenseExpression(), nil
+}
+
+// GetParameters loads a license from the given path and returns its license specific parameters.
+func (s Service) GetParameters(path string) (Parameters, error) {
+	license, err := s.load(path)
+	if err != nil {
+		return Parameters{}, err
+	}
+
+	return license.parameters, nil
+}
+
 func (s Service) loadSetFlush(path string, op func(license *License) error) error {
 	license, err := s.load(path)
 	if err != nil {
@@ -86,13 +159,55 @@ func (s Service) UpdateProjectName(path string, name string) error {
 	})
 }
 
-// UpdateHolder loads a license from the given path, updates its copyright holder, and writes it back.
+// UpdateHolder loads a license from the given path, updates the holder of its primary copyright, and writes it back.
 func (s Service) UpdateHolder(path string, holder string) error {
 	return s.loadSetFlush(path, func(license *License) error {
 		return license.SetHolder(holder)
 	})
 }
 
+// GetCopyrights loads a license from the given path and returns its copyright statements, the primary one first.
+func (s Service) GetCopyrights(path string) (Copyrights, error) {
+	license, err := s.load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return license.copyrights, nil
+}
+
+// GetUnparsedCopyrights loads a license from the given path and returns the lines that look like a copyright
+// statement but couldn't be parsed. Their lines are counted in the file the copyrights are read from,
+// the NOTICE for licenses that require one, or the REUSE.toml. These copyrights are left out when the
+// license files are written back, a REUSE.toml keeps them as they are.
+func (s Service) GetUnparsedCopyrights(path string) ([]UnparsedCopyright, error) {
+	license, err := s.load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return license.unparsedCopyrights, nil
+}
+
+// AddHolder loads a license from the given path, adds a copyright statement for another holder, and writes it back.
+func (s Service) AddHolder(path string, holder string, start, end int) error {
+	copyright, err := s.policy.NewCopyright(holder, start, end)
+	if err != nil {
+		return err
+	}
+
+	return s.loadSetFlush(path, func(license *License) error {
+		return license.AddCopyright(copyright)
+	})
+}
+
+// RemoveHolder loads a license from the given path, removes the copyright statement of a holder, and writes it back.
+func (s Service) RemoveHolder(path string, holder string) error {
+	return s.loadSetFlush(path, func(license *License) error {
+		return license.RemoveHolder(holder)
+	})
+}
+
 // UpdateStartYear loads a license from the given path, updates its copyright start year, and writes it back.
 func (s Service) UpdateStartYear(path string, year int) error {
 	return s.loadSetFlush(path, func(license *License) error {
@@ -106,3 +221,266 @@ func (s Service) UpdateEndYear(path string, year int) error {
 		return license.SetCopyrightEndYear(year)
 	})
 }
+
+// UpdateYears loads a license from the given path, updates the years of its primary copyright, and writes it back.
+// The years are written as in a copyright line, e.g. "2018, 2020-2022, 2024" or "2019-present".
+func (s Service) UpdateYears(path string, years string) error {
+	parsed, err := ParseYears(years)
+	if err != nil {
+		return err
+	}
+
+	return s.loadSetFlush(path, func(license *License) error {
+		return license.SetCopyrightYears(parsed)
+	})
+}
+
+// BumpYearOptions configures Service.BumpYear.
+type BumpYearOptions struct {
+	// HeadersRoot, when set, is the directory whose source file headers are bumped along with the license
+	HeadersRoot string
+	// ModifiedYear tells which source files changed this year, the file modification time is used when nil.
+	// GitHistory.ModifiedYear reads it from the commits instead.
+	ModifiedYear ModifiedYearFunc
+}
+
+// YearBumpSummary describes what Service.BumpYear changed.
+type YearBumpSummary struct {
+	Previous CopyrightYears
+	Current  CopyrightYears
+	// LicenseUpdated is true when the license files were written back
+	LicenseUpdated bool
+	// Headers are the source files whose header was updated
+	Headers []HeaderChange
+}
+
+// BumpYear loads a license from the given path, extends its primary copyright to the current year, and writes it back.
+// The current year is taken from the clock of the service's validation policy.
+// A range that starts and ends on the same year is collapsed into that year.
+// When options.HeadersRoot is set, the headers of the source files under it modified this year are bumped as well.
+func (s Service) BumpYear(path string, options BumpYearOptions) (YearBumpSummary, error) {
+	year := s.policy.currentYear()
+
+	license, err := s.load(path)
+	if err != nil {
+		return YearBumpSummary{}, err
+	}
+
+	primary := license.primaryCopyright()
+
+	summary := YearBumpSummary{
+		Previous: CopyrightYears{Start: primary.StartYear, End: primary.EndYear},
+	}
+
+	summary.LicenseUpdated, err = primary.BumpYear(year)
+	if err != nil {
+		return YearBumpSummary{}, err
+	}
+
+	summary.Current = CopyrightYears{Start: primary.StartYear, End: primary.EndYear}
+
+	if summary.LicenseUpdated {
+		if err = s.repo.Write(license); err != nil {
+			return YearBumpSummary{}, err
+		}
+	}
+
+	if options.HeadersRoot == "" {
+		return summary, nil
+	}
+
+	modifiedYear := options.ModifiedYear
+	if modifiedYear == nil {
+		modifiedYear = FileModifiedYear
+	}
+
+	summary.Headers, err = BumpHeaderYears(options.HeadersRoot, primary.Holder, year, modifiedYear)
+	if err != nil {
+		return YearBumpSummary{}, err
+	}
+
+	return summary, nil
+}
+
+// UpdateSecondaryLicense loads a license from the given path, updates the Secondary License declared in its notice, and writes it back.
+// An empty name removes the declaration.
+func (s Service) UpdateSecondaryLicense(path string, name string) error {
+	return s.loadSetFlush(path, func(license *License) error {
+		return license.SetSecondaryLicense(name)
+	})
+}
+
+// UpdateChangeDate loads a license from the given path, updates its Business Source License change date, and writes it back.
+func (s Service) UpdateChangeDate(path string, date time.Time) error {
+	return s.loadSetFlush(path, func(license *License) error {
+		return license.SetChangeDate(date)
+	})
+}
+
+func (s Service) annotator() (Annotator, error) {
+	annotator, ok := s.repo.(Annotator)
+	if !ok {
+		return nil, UnsupportedRepositoryError
+	}
+
+	return annotator, nil
+}
+
+// GetAnnotations returns the licensing annotations stored at the given path.
+func (s Service) GetAnnotations(path string) ([]ReuseAnnotation, error) {
+	annotator, err := s.annotator()
+	if err != nil {
+		return nil, err
+	}
+
+	return annotator.LoadAnnotations(path)
+}
+
+// Annotate adds an annotation to the ones stored at the given path, replacing the annotation
+// for the same paths if there is one.
+func (s Service) Annotate(path string, annotation ReuseAnnotation) error {
+	if err := annotation.Validate(); err != nil {
+		return err
+	}
+
+	annotator, err := s.annotator()
+	if err != nil {
+		return err
+	}
+
+	annotations, err := annotator.LoadAnnotations(path)
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for idx := range annotations {
+		if annotations[idx].samePaths(annotation) {
+			annotations[idx] = annotation
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		annotations = append(annotations, annotation)
+	}
+
+	return annotator.WriteAnnotations(path, annotations)
+}
+
+// RemoveAnnotation removes the annotation for the given paths from the ones stored at path.
+func (s Service) RemoveAnnotation(path string, paths []string) error {
+	annotator, err := s.annotator()
+	if err != nil {
+		return err
+	}
+
+	annotations, err := annotator.LoadAnnotations(path)
+	if err != nil {
+		return err
+	}
+
+	target := ReuseAnnotation{Paths: paths}
+	kept := make([]ReuseAnnotation, 0, len(annotations))
+	for _, annotation := range annotations {
+		if !annotation.samePaths(target) {
+			kept = append(kept, annotation)
+		}
+	}
+
+	if len(kept) == len(annotations) {
+		return AnnotationNotFoundError
+	}
+
+	return annotator.WriteAnnotations(path, kept)
+}
+
+// Lint checks the licensing information of the project.
+func (s Service) Lint() (ReuseLintReport, error) {
+	linter, ok := s.repo.(Linter)
+	if !ok {
+		return ReuseLintReport{}, UnsupportedRepositoryError
+	}
+
+	return linter.Lint()
+}
+
+// ApplyHeaders loads a license from the given path and adds its header to every source file under root,
+// updating the headers that are out of date.
+func (s Service) ApplyHeaders(path string, root string) ([]HeaderChange, error) {
+	license, err := s.load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ApplyHeaders(root, NewHeader(license))
+}
+
+// CheckHeaders loads a license from the given path and checks the header of every source file under root
+// against it, without changing any of them.
+func (s Service) CheckHeaders(path string, root string) ([]HeaderFinding, error) {
+	license, err := s.load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return CheckHeaders(root, NewHeader(license))
+}
+
+// CopyrightHistory derives copyrights from the history of a project, like GitHistory does from its commits.
+type CopyrightHistory interface {
+	Copyright(path string) (Copyright, error)
+}
+
+// historyWithPolicy has a GitHistory validate with the service's policy, unless it was given one of its own
+func (s Service) historyWithPolicy(history CopyrightHistory) CopyrightHistory {
+	if git, ok := history.(GitHistory); ok && git.options.Policy == nil {
+		git.options.Policy = &s.policy
+		return git
+	}
+
+	return history
+}
+
+// ApplyHeadersFromHistory loads a license from the given path and adds its header to every source file under root,
+// with the copyright of each file taken from the history.
+func (s Service) ApplyHeadersFromHistory(path string, root string, history CopyrightHistory) ([]HeaderChange, error) {
+	license, err := s.load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	expression := license.licenseExpression().String()
+	history = s.historyWithPolicy(history)
+
+	return ApplyHeadersFunc(root, func(path string) (Header, error) {
+		copyright, err := history.Copyright(path)
+		if err != nil {
+			return Header{}, err
+		}
+
+		return Header{Copyrights: Copyrights{copyright}, Expression: expression}, nil
+	})
+}
+
+// UpdateYearsFromHistory loads a license from the given path, sets its copyright years to those of the
+// project's history, and writes it back.
+func (s Service) UpdateYearsFromHistory(path string, history CopyrightHistory) error {
+	copyright, err := s.historyWithPolicy(history).Copyright("")
+	if err != nil {
+		return err
+	}
+
+	return s.loadSetFlush(path, func(license *License) error {
+		license.primaryCopyright().EndYear = 0
+		if err := license.SetCopyrightStartYear(copyright.StartYear); err != nil {
+			return err
+		}
+
+		if copyright.EndYear == 0 {
+			return nil
+		}
+
+		return license.SetCopyrightEndYear(copyright.EndYear)
+	})
+}
@@ -1,11 +1,16 @@
 package ligen
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"reflect"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 type FakeRepo struct {
@@ -33,6 +38,18 @@ func (f *FakeRepo) Load(path string, license *License) error {
 		return strings.NewReader(f.files["NOTICE"]), func() error { return nil }, nil
 	}
 
+	if expression, err := ParseLicenseIdentifier(f.files[path]); err == nil && expression.Normalize().requiresCombinedStatement() {
+		licenseLoaders := make(map[LicenseType]loader)
+		for licenseType, licensePath := range combinedLicensePaths(expression.LicenseTypes()) {
+			content := f.files[licensePath]
+			licenseLoaders[licenseType] = func() (io.Reader, func() error, error) {
+				return strings.NewReader(content), func() error { return nil }, nil
+			}
+		}
+
+		return LoadCombined(license, expression, licenseLoaders, nl)
+	}
+
 	return Load(license, ll, nl)
 }
 
@@ -92,7 +109,7 @@ func TestServiceCreate(t *testing.T) {
 				start:       2025,
 				end:         2025,
 				holder:      "Peanut Butter",
-				licenseType: GNU_LESSER_3_0,
+				licenseType: GNU_LESSER_3_0_OR_LATER,
 				projectName: "Ligen",
 			},
 			fileToCheck: "COPYING.LESSER",
@@ -130,24 +147,46 @@ func TestServiceCreate(t *testing.T) {
 			},
 			fileToCheck: "UNLICENSE",
 		},
+		{
+			name: "Pass-BSD-3-Clause",
+			input: input{
+				start:       2025,
+				end:         2025,
+				holder:      "Peanut Butter",
+				licenseType: BSD_3_CLAUSE,
+				projectName: "Ligen",
+			},
+			fileToCheck: "LICENSE",
+		},
+		{
+			name: "Pass-Proprietary",
+			input: input{
+				start:       2025,
+				end:         2025,
+				holder:      "Peanut Butter",
+				licenseType: PROPRIETARY,
+				projectName: "Ligen",
+			},
+			fileToCheck: "LICENSE",
+		},
 	}
 
 	for _, tc := range tests {
 		repo := NewFakeRepo()
-		svc := NewService(&repo)
+		svc := NewServiceWithPolicy(&repo, testPolicy)
 
 		t.Run(tc.name, func(t *testing.T) {
 			// Given
 			expected := License{
-				copyright: Copyright{
+				copyrights: Copyrights{{
 					Holder:    tc.input.holder,
 					StartYear: tc.input.start,
 					EndYear:   tc.input.end,
-				},
+				}},
 				licenseType: tc.input.licenseType,
 			}
 
-			if slices.Contains([]LicenseType{MOZILLA_2_0, GNU_LESSER_3_0, APACHE_2_0}, tc.input.licenseType) {
+			if slices.Contains([]LicenseType{MOZILLA_2_0, GNU_LESSER_3_0_OR_LATER, APACHE_2_0}, tc.input.licenseType) {
 				expected.projectName = tc.input.projectName
 			}
 
@@ -222,7 +261,7 @@ func TestServiceGetYears(t *testing.T) {
 				start:       2025,
 				end:         2025,
 				holder:      "Peanut Butter",
-				licenseType: GNU_LESSER_3_0,
+				licenseType: GNU_LESSER_3_0_OR_LATER,
 				projectName: "Ligen",
 			},
 			fileToCheck: "COPYING.LESSER",
@@ -242,7 +281,7 @@ func TestServiceGetYears(t *testing.T) {
 
 	for _, tc := range tests {
 		repo := NewFakeRepo()
-		svc := NewService(&repo)
+		svc := NewServiceWithPolicy(&repo, testPolicy)
 
 		t.Run(tc.name, func(t *testing.T) {
 			expected := CopyrightYears{Start: tc.input.start, End: tc.input.end}
@@ -314,7 +353,7 @@ func TestServiceGetLicenseType(t *testing.T) {
 				start:       2025,
 				end:         2025,
 				holder:      "Peanut Butter",
-				licenseType: GNU_LESSER_3_0,
+				licenseType: GNU_LESSER_3_0_OR_LATER,
 				projectName: "Ligen",
 			},
 			fileToCheck: "COPYING.LESSER",
@@ -352,11 +391,44 @@ func TestServiceGetLicenseType(t *testing.T) {
 			},
 			fileToCheck: "UNLICENSE",
 		},
+		{
+			name: "Pass-GNUGeneral-2.0-Only",
+			input: input{
+				start:       2025,
+				end:         2025,
+				holder:      "Peanut Butter",
+				licenseType: GNU_GENERAL_2_0_ONLY,
+				projectName: "Ligen",
+			},
+			fileToCheck: "COPYING",
+		},
+		{
+			name: "Pass-GNUGeneral-3.0-OrLater",
+			input: input{
+				start:       2025,
+				end:         2025,
+				holder:      "Peanut Butter",
+				licenseType: GNU_GENERAL_3_0_OR_LATER,
+				projectName: "Ligen",
+			},
+			fileToCheck: "COPYING",
+		},
+		{
+			name: "Pass-GNUAffero-3.0-OrLater",
+			input: input{
+				start:       2025,
+				end:         2025,
+				holder:      "Peanut Butter",
+				licenseType: GNU_AFFERO_3_0_OR_LATER,
+				projectName: "Ligen",
+			},
+			fileToCheck: "COPYING",
+		},
 	}
 
 	for _, tc := range tests {
 		repo := NewFakeRepo()
-		svc := NewService(&repo)
+		svc := NewServiceWithPolicy(&repo, testPolicy)
 
 		t.Run(tc.name, func(t *testing.T) {
 			err := svc.Create(
@@ -429,7 +501,7 @@ func TestServiceUpdateHolder(t *testing.T) {
 				start:       2023,
 				end:         2025,
 				holder:      "Peanut Butter",
-				licenseType: GNU_LESSER_3_0,
+				licenseType: GNU_LESSER_3_0_OR_LATER,
 				projectName: "Ligen",
 			},
 			fileToCheck: "COPYING.LESSER",
@@ -447,11 +519,23 @@ func TestServiceUpdateHolder(t *testing.T) {
 			fileToCheck: "LICENSE",
 			newHolder:   "Jelly",
 		},
+		{
+			name: "Pass-Proprietary",
+			input: input{
+				start:       2023,
+				end:         2025,
+				holder:      "Peanut Butter",
+				licenseType: PROPRIETARY,
+				projectName: "Ligen",
+			},
+			fileToCheck: "LICENSE",
+			newHolder:   "Jelly",
+		},
 	}
 
 	for _, tc := range tests {
 		repo := NewFakeRepo()
-		svc := NewService(&repo)
+		svc := NewServiceWithPolicy(&repo, testPolicy)
 
 		t.Run(tc.name, func(t *testing.T) {
 			err := svc.Create(
@@ -479,8 +563,8 @@ func TestServiceUpdateHolder(t *testing.T) {
 				t.FailNow()
 			}
 
-			if license.copyright.Holder != tc.newHolder {
-				t.Errorf("Expected %s, got %s", license.copyright.Holder, tc.newHolder)
+			if license.copyrights.Holder() != tc.newHolder {
+				t.Errorf("Expected %s, got %s", license.copyrights.Holder(), tc.newHolder)
 			}
 		})
 	}
@@ -520,7 +604,7 @@ func TestServiceUpdateProjectName(t *testing.T) {
 				start:       2023,
 				end:         2025,
 				holder:      "Peanut Butter",
-				licenseType: GNU_LESSER_3_0,
+				licenseType: GNU_LESSER_3_0_OR_LATER,
 				projectName: "Ligen",
 			},
 			fileToCheck:    "COPYING.LESSER",
@@ -542,7 +626,7 @@ func TestServiceUpdateProjectName(t *testing.T) {
 
 	for _, tc := range tests {
 		repo := NewFakeRepo()
-		svc := NewService(&repo)
+		svc := NewServiceWithPolicy(&repo, testPolicy)
 
 		t.Run(tc.name, func(t *testing.T) {
 			err := svc.Create(
@@ -623,7 +707,7 @@ func TestServiceUpdateStartYear(t *testing.T) {
 				start:       2023,
 				end:         2025,
 				holder:      "Peanut Butter",
-				licenseType: GNU_LESSER_3_0,
+				licenseType: GNU_LESSER_3_0_OR_LATER,
 				projectName: "Ligen",
 			},
 			fileToCheck:  "COPYING.LESSER",
@@ -645,7 +729,7 @@ func TestServiceUpdateStartYear(t *testing.T) {
 
 	for _, tc := range tests {
 		repo := NewFakeRepo()
-		svc := NewService(&repo)
+		svc := NewServiceWithPolicy(&repo, testPolicy)
 
 		t.Run(tc.name, func(t *testing.T) {
 			err := svc.Create(
@@ -673,8 +757,8 @@ func TestServiceUpdateStartYear(t *testing.T) {
 				t.FailNow()
 			}
 
-			if license.copyright.StartYear != tc.newStartYear {
-				t.Errorf("Expected %d, got %d", license.copyright.StartYear, tc.newStartYear)
+			if license.copyrights.StartYear() != tc.newStartYear {
+				t.Errorf("Expected %d, got %d", license.copyrights.StartYear(), tc.newStartYear)
 			}
 		})
 	}
@@ -726,7 +810,7 @@ func TestServiceUpdateEndYear(t *testing.T) {
 				start:       2023,
 				end:         2025,
 				holder:      "Peanut Butter",
-				licenseType: GNU_LESSER_3_0,
+				licenseType: GNU_LESSER_3_0_OR_LATER,
 				projectName: "Ligen",
 			},
 			fileToCheck: "COPYING.LESSER",
@@ -748,7 +832,7 @@ func TestServiceUpdateEndYear(t *testing.T) {
 
 	for _, tc := range tests {
 		repo := NewFakeRepo()
-		svc := NewService(&repo)
+		svc := NewServiceWithPolicy(&repo, testPolicy)
 
 		t.Run(tc.name, func(t *testing.T) {
 			err := svc.Create(
@@ -776,9 +860,866 @@ func TestServiceUpdateEndYear(t *testing.T) {
 				t.FailNow()
 			}
 
-			if license.copyright.EndYear != tc.newEndYear {
-				t.Errorf("Expected %d, got %d", license.copyright.EndYear, tc.newEndYear)
+			if license.copyrights.EndYear() != tc.newEndYear {
+				t.Errorf("Expected %d, got %d", license.copyrights.EndYear(), tc.newEndYear)
+			}
+		})
+	}
+}
+
+func TestServiceUpdateSecondaryLicense(t *testing.T) {
+	tests := []struct {
+		name                string
+		initial             string
+		newSecondaryLicense string
+		errorMessage        string
+	}{
+		{
+			name:                "Pass-Declare",
+			initial:             "",
+			newSecondaryLicense: GNUGeneral2SecondaryLicense,
+		},
+		{
+			name:                "Pass-Replace",
+			initial:             GNUGeneral2SecondaryLicense,
+			newSecondaryLicense: "GNU General Public License, version 2 or later",
+		},
+		{
+			name:                "Pass-Remove",
+			initial:             GNUGeneral2SecondaryLicense,
+			newSecondaryLicense: "",
+		},
+		{
+			name:                "Fail-TooLong",
+			initial:             "",
+			newSecondaryLicense: strings.Repeat("a", MAX_SECONDARY_LICENSE_LENGTH+1),
+			errorMessage:        SecondaryLicenseTooLongError.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		repo := NewFakeRepo()
+		svc := NewServiceWithPolicy(&repo, testPolicy)
+
+		t.Run(tc.name, func(t *testing.T) {
+			license, err := New("Ligen", "Peanut Butter", 2024, 0, ECLIPSE_2_0)
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+
+			if err = license.SetSecondaryLicense(tc.initial); err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+
+			if err = repo.Write(license); err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+
+			err = svc.UpdateSecondaryLicense("LICENSE", tc.newSecondaryLicense)
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			var loaded License
+			err = repo.Load("LICENSE", &loaded)
+			if err != nil {
+				t.Error(err.Error())
+				t.FailNow()
+			}
+
+			if loaded.licenseType != ECLIPSE_2_0 {
+				t.Errorf("Expected %s, got %s", ECLIPSE_2_0.String(), loaded.licenseType.String())
+			}
+
+			if loaded.parameters.SecondaryLicense != tc.newSecondaryLicense {
+				t.Errorf("Expected %q, got %q", tc.newSecondaryLicense, loaded.parameters.SecondaryLicense)
+			}
+		})
+	}
+}
+
+func TestServiceUpdateChangeDate(t *testing.T) {
+	parameters := Parameters{
+		Licensor:           "Peanut Butter Inc.",
+		LicensedWork:       "Ligen 1.0",
+		AdditionalUseGrant: "None",
+		ChangeDate:         time.Date(2028, 6, 1, 0, 0, 0, 0, time.UTC),
+		ChangeLicense:      "Apache License, Version 2.0",
+	}
+
+	tests := []struct {
+		name          string
+		newChangeDate time.Time
+		errorMessage  string
+	}{
+		{
+			name:          "Pass",
+			newChangeDate: time.Date(2029, 6, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "Fail-ZeroDate",
+			newChangeDate: time.Time{},
+			errorMessage:  MissingChangeDateError.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		repo := NewFakeRepo()
+		svc := NewServiceWithPolicy(&repo, testPolicy)
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := svc.CreateWithParameters("Ligen", "Peanut Butter", 2024, 0, BUSINESS_SOURCE_1_1, parameters)
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+
+			err = svc.UpdateChangeDate("LICENSE", tc.newChangeDate)
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			loaded, err := svc.GetParameters("LICENSE")
+			if err != nil {
+				t.Error(err.Error())
+				t.FailNow()
+			}
+
+			expected := parameters
+			expected.ChangeDate = tc.newChangeDate
+
+			if !reflect.DeepEqual(expected, loaded) {
+				t.Errorf("Expected %+v, got %+v", expected, loaded)
+			}
+		})
+	}
+}
+
+func TestServiceUpdateHolderKeepsProprietaryParameters(t *testing.T) {
+	tests := []struct {
+		name         string
+		parameters   Parameters
+		errorMessage string
+	}{
+		{
+			name: "Pass-ClauseAndEmail",
+			parameters: Parameters{
+				ConfidentialityClause: DefaultConfidentialityClause,
+				ContactEmail:          "legal@example.com",
+			},
+		},
+		{
+			name: "Pass-CustomClause",
+			parameters: Parameters{
+				ConfidentialityClause: "The Software is a trade secret of the copyright holder. Employees and contractors may only access it as part of their assigned duties, and must not copy it to personal devices or share it outside of the organization under any circumstances.",
+			},
+		},
+		{
+			name: "Pass-EmailOnly",
+			parameters: Parameters{
+				ContactEmail: "legal@example.com",
+			},
+		},
+		{
+			name: "Fail-InvalidEmail",
+			parameters: Parameters{
+				ContactEmail: "Legal <legal@example.com>",
+			},
+			errorMessage: InvalidContactEmailError.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		repo := NewFakeRepo()
+		svc := NewServiceWithPolicy(&repo, testPolicy)
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := svc.CreateWithParameters("Ligen", "Peanut Butter", 2023, 0, PROPRIETARY, tc.parameters)
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			if err = svc.UpdateHolder("LICENSE", "Jelly"); err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+
+			var license License
+			if err = repo.Load("LICENSE", &license); err != nil {
+				t.Error(err.Error())
+				t.FailNow()
+			}
+
+			if license.licenseType != PROPRIETARY {
+				t.Errorf("Expected %s, got %s", PROPRIETARY.String(), license.licenseType.String())
+			}
+
+			if license.copyrights.Holder() != "Jelly" {
+				t.Errorf("Expected Jelly, got %s", license.copyrights.Holder())
+			}
+
+			if !reflect.DeepEqual(tc.parameters, license.parameters) {
+				t.Errorf("Expected %+v, got %+v", tc.parameters, license.parameters)
+			}
+		})
+	}
+}
+
+func TestServiceAddRemoveHolder(t *testing.T) {
+	tests := []struct {
+		name        string
+		licenseType LicenseType
+		fileToCheck string
+	}{
+		{name: "Pass-MIT", licenseType: MIT, fileToCheck: "LICENSE"},
+		{name: "Pass-BSD-4-Clause", licenseType: BSD_4_CLAUSE, fileToCheck: "LICENSE"},
+		{name: "Pass-PostgreSQL", licenseType: POSTGRESQL, fileToCheck: "LICENSE"},
+		{name: "Pass-Apache-2.0", licenseType: APACHE_2_0, fileToCheck: "LICENSE"},
+		{name: "Pass-GNU-General-3.0", licenseType: GNU_GENERAL_3_0_ONLY, fileToCheck: "COPYING"},
+		{name: "Pass-Eclipse-2.0", licenseType: ECLIPSE_2_0, fileToCheck: "LICENSE"},
+		{name: "Pass-PolyForm-Noncommercial", licenseType: POLYFORM_NONCOMMERCIAL_1_0_0, fileToCheck: "LICENSE"},
+		{name: "Pass-Proprietary", licenseType: PROPRIETARY, fileToCheck: "LICENSE"},
+	}
+
+	for _, tc := range tests {
+		repo := NewFakeRepo()
+		svc := NewServiceWithPolicy(&repo, testPolicy)
+
+		t.Run(tc.name, func(t *testing.T) {
+			if err := svc.Create("Ligen", "Peanut Butter", 2019, 0, tc.licenseType); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := svc.AddHolder(tc.fileToCheck, "Jelly", 2021, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			copyrights, err := svc.GetCopyrights(tc.fileToCheck)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := Copyrights{
+				{Holder: "Peanut Butter", StartYear: 2019},
+				{Holder: "Jelly", StartYear: 2021},
+			}
+			if !reflect.DeepEqual(expected, copyrights) {
+				t.Errorf("Expected %+v, got %+v", expected, copyrights)
+			}
+
+			checkError(DuplicateHolderError.Error(), svc.AddHolder(tc.fileToCheck, "Jelly", 2022, 0), t)
+			checkError(HolderNotFoundError.Error(), svc.RemoveHolder(tc.fileToCheck, "Marmalade"), t)
+
+			if err = svc.RemoveHolder(tc.fileToCheck, "Peanut Butter"); err != nil {
+				t.Fatal(err)
+			}
+
+			copyrights, err = svc.GetCopyrights(tc.fileToCheck)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected = Copyrights{{Holder: "Jelly", StartYear: 2021}}
+			if !reflect.DeepEqual(expected, copyrights) {
+				t.Errorf("Expected %+v, got %+v", expected, copyrights)
+			}
+
+			checkError(LastHolderError.Error(), svc.RemoveHolder(tc.fileToCheck, "Jelly"), t)
+		})
+	}
+}
+
+func TestServiceUpdateYears(t *testing.T) {
+	tests := []struct {
+		name         string
+		licenseType  LicenseType
+		fileToCheck  string
+		years        string
+		expectedLine string
+		errorMessage string
+	}{
+		{
+			name:         "Pass-MIT-List",
+			licenseType:  MIT,
+			fileToCheck:  "LICENSE",
+			years:        "2018, 2020-2022, 2024",
+			expectedLine: "Copyright (c) 2018, 2020-2022, 2024 Peanut Butter",
+		},
+		{
+			name:         "Pass-GNU-General-3.0-Present",
+			licenseType:  GNU_GENERAL_3_0_ONLY,
+			fileToCheck:  "COPYING",
+			years:        "2015, 2019-present",
+			expectedLine: "Copyright (C) 2015, 2019-present Peanut Butter",
+		},
+		{
+			name:         "Fail-Overlap",
+			licenseType:  MIT,
+			fileToCheck:  "LICENSE",
+			years:        "2018-2021, 2020",
+			errorMessage: OverlappingYearsError.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		repo := NewFakeRepo()
+		svc := NewServiceWithPolicy(&repo, testPolicy)
+
+		t.Run(tc.name, func(t *testing.T) {
+			if err := svc.Create("Ligen", "Peanut Butter", 2018, 0, tc.licenseType); err != nil {
+				t.Fatal(err)
+			}
+
+			err := svc.UpdateYears(tc.fileToCheck, tc.years)
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			rendered := repo.files[tc.fileToCheck]
+			if tc.licenseType.RequiresNotice() {
+				rendered = repo.files["NOTICE"]
+			}
+
+			if !strings.Contains(rendered, tc.expectedLine+"\n") {
+				t.Errorf("Expected the copyright line %q, got %s", tc.expectedLine, rendered)
+			}
+
+			copyrights, err := svc.GetCopyrights(tc.fileToCheck)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if copyrights[0].Years().String() != tc.years {
+				t.Errorf("Expected %s, got %s", tc.years, copyrights[0].Years().String())
 			}
 		})
 	}
 }
+
+func TestServiceKeepsHolderEmailAndContributors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := MITTemplate.Execute(&buf, Copyrights{{Holder: "Peanut Butter", StartYear: 2019}}); err != nil {
+		t.Fatal(err)
+	}
+
+	content := strings.Replace(buf.String(), "Copyright (c) 2019 Peanut Butter", "Copyright (c) 2019 Peanut Butter <pb@example.com> and contributors. All rights reserved.", 1)
+
+	repo := NewFakeRepo(Writeable{Path: "LICENSE", Content: content})
+	svc := NewServiceWithPolicy(&repo, testPolicy)
+
+	if err := svc.UpdateStartYear("LICENSE", 2018); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedLine := "Copyright (c) 2018 Peanut Butter <pb@example.com> and contributors\n"
+	if !strings.Contains(repo.files["LICENSE"], expectedLine) {
+		t.Errorf("Expected the copyright line %q, got %s", expectedLine, repo.files["LICENSE"])
+	}
+
+	copyrights, err := svc.GetCopyrights("LICENSE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Copyrights{{Holder: "Peanut Butter", Email: "pb@example.com", Contributors: true, StartYear: 2018}}
+	if !reflect.DeepEqual(expected, copyrights) {
+		t.Errorf("Expected %+v, got %+v", expected, copyrights)
+	}
+}
+
+func TestServiceGetUnparsedCopyrights(t *testing.T) {
+	var buf bytes.Buffer
+	if err := MITTemplate.Execute(&buf, Copyrights{{Holder: "Peanut Butter", StartYear: 2019}}); err != nil {
+		t.Fatal(err)
+	}
+
+	content := strings.Replace(buf.String(), "Copyright (c) 2019 Peanut Butter\n", "Copyright (c) 2019 Peanut Butter\nCopyright (c) 2024-2019 Jelly\n", 1)
+
+	repo := NewFakeRepo(Writeable{Path: "LICENSE", Content: content})
+	svc := NewServiceWithPolicy(&repo, testPolicy)
+
+	unparsed, err := svc.GetUnparsedCopyrights("LICENSE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(unparsed) != 1 {
+		t.Fatalf("Expected a single unparsed copyright, got %+v", unparsed)
+	}
+
+	if unparsed[0].Line != 4 || unparsed[0].Text != "Copyright (c) 2024-2019 Jelly" || unparsed[0].Err == nil {
+		t.Errorf("Expected line 4 to be reported with its error, got %+v", unparsed[0])
+	}
+
+	copyrights, err := svc.GetCopyrights("LICENSE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Copyrights{{Holder: "Peanut Butter", StartYear: 2019}}
+	if !reflect.DeepEqual(expected, copyrights) {
+		t.Errorf("Expected %+v, got %+v", expected, copyrights)
+	}
+}
+
+func TestServiceCreateFromExpression(t *testing.T) {
+	tests := []struct {
+		name         string
+		expression   Expression
+		errorMessage string
+	}{
+		{
+			name: "Pass-MIT-Or-Apache",
+			expression: Expression{
+				Operator: OR_EXPRESSION,
+				Operands: []Expression{
+					{Operator: SIMPLE_EXPRESSION, LicenseType: MIT},
+					{Operator: SIMPLE_EXPRESSION, LicenseType: APACHE_2_0},
+				},
+			},
+		},
+		{
+			name: "Fail-SingleOperand",
+			expression: Expression{
+				Operator: OR_EXPRESSION,
+				Operands: []Expression{
+					{Operator: SIMPLE_EXPRESSION, LicenseType: MIT},
+				},
+			},
+			errorMessage: "invalid license expression: OR requires at least two operands",
+		},
+	}
+
+	for _, tc := range tests {
+		repo := NewFakeRepo()
+		svc := NewServiceWithPolicy(&repo, testPolicy)
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := svc.CreateFromExpression("Ligen", "Peanut Butter", 2023, 0, tc.expression)
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			// The layout survives an update
+			if err = svc.UpdateHolder("LICENSE", "Jelly"); err != nil {
+				t.Fatal(err)
+			}
+
+			expression, err := svc.GetExpression("LICENSE")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tc.expression, expression) {
+				t.Errorf("Expected %s, got %s", tc.expression.String(), expression.String())
+			}
+
+			if !strings.Contains(repo.files["LICENSE-MIT"], "Jelly") {
+				t.Errorf("Expected LICENSE-MIT to name the new holder, got %s", repo.files["LICENSE-MIT"])
+			}
+		})
+	}
+}
+
+func TestServiceAnnotate(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  []ReuseAnnotation
+		expected     []ReuseAnnotation
+		errorMessage string
+	}{
+		{
+			name: "Pass-Added",
+			annotations: []ReuseAnnotation{
+				{Paths: []string{"docs/**"}, Copyright: []string{"2024 Peanut Butter"}, License: "CC-BY-4.0"},
+			},
+			expected: []ReuseAnnotation{
+				{Paths: []string{"**"}, Copyright: []string{"2024 Peanut Butter"}, License: "MIT"},
+				{Paths: []string{"docs/**"}, Copyright: []string{"2024 Peanut Butter"}, License: "CC-BY-4.0"},
+			},
+		},
+		{
+			name: "Pass-SamePathsReplaced",
+			annotations: []ReuseAnnotation{
+				{Paths: []string{"docs/**"}, License: "CC-BY-4.0"},
+				{Paths: []string{"docs/**"}, Precedence: PRECEDENCE_OVERRIDE, License: "CC0-1.0"},
+			},
+			expected: []ReuseAnnotation{
+				{Paths: []string{"**"}, Copyright: []string{"2024 Peanut Butter"}, License: "MIT"},
+				{Paths: []string{"docs/**"}, Precedence: PRECEDENCE_OVERRIDE, License: "CC0-1.0"},
+			},
+		},
+		{
+			name: "Fail-UnknownLicense",
+			annotations: []ReuseAnnotation{
+				{Paths: []string{"docs/**"}, License: "WTFPL"},
+			},
+			errorMessage: "invalid license type: WTFPL",
+		},
+		{
+			name: "Fail-InvalidPrecedence",
+			annotations: []ReuseAnnotation{
+				{Paths: []string{"docs/**"}, Precedence: "closer", License: "MIT"},
+			},
+			errorMessage: InvalidPrecedenceError.Error(),
+		},
+		{
+			name: "Fail-Empty",
+			annotations: []ReuseAnnotation{
+				{Paths: []string{"docs/**"}},
+			},
+			errorMessage: EmptyAnnotationError.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewService(NewReuseRepository(t.TempDir()))
+			if err := svc.Create("Ligen", "Peanut Butter", 2024, 0, MIT); err != nil {
+				t.Fatal(err)
+			}
+
+			var err error
+			for _, annotation := range tc.annotations {
+				if err = svc.Annotate(REUSE_TOML_PATH, annotation); err != nil {
+					break
+				}
+			}
+
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			annotations, err := svc.GetAnnotations(REUSE_TOML_PATH)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, annotations) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, annotations)
+			}
+		})
+	}
+}
+
+func TestServiceRemoveAnnotation(t *testing.T) {
+	svc := NewService(NewReuseRepository(t.TempDir()))
+	if err := svc.Create("Ligen", "Peanut Butter", 2024, 0, MIT); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.Annotate(REUSE_TOML_PATH, ReuseAnnotation{Paths: []string{"docs/**"}, License: "CC-BY-4.0"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.RemoveAnnotation(REUSE_TOML_PATH, []string{"docs/**"}); err != nil {
+		t.Fatal(err)
+	}
+
+	checkError(AnnotationNotFoundError.Error(), svc.RemoveAnnotation(REUSE_TOML_PATH, []string{"docs/**"}), t)
+
+	// The project still reads back after its annotations change
+	licenseType, err := svc.GetLicenseType(REUSE_TOML_PATH)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if licenseType != MIT {
+		t.Errorf("Expected %s, got %s", MIT.String(), licenseType.String())
+	}
+}
+
+func TestServiceLint(t *testing.T) {
+	root := t.TempDir()
+	svc := NewService(NewReuseRepository(root))
+	if err := svc.Create("Ligen", "Peanut Butter", 2024, 0, APACHE_2_0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := svc.Lint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The annotation covering the project covers the new file, and its NOTICE
+	if !report.Compliant() {
+		t.Errorf("Expected a compliant project, got %+v", report)
+	}
+}
+
+func TestServiceUnsupportedRepository(t *testing.T) {
+	repo := NewFakeRepo()
+	svc := NewServiceWithPolicy(&repo, testPolicy)
+
+	_, err := svc.Lint()
+	checkError(UnsupportedRepositoryError.Error(), err, t)
+
+	err = svc.Annotate(REUSE_TOML_PATH, ReuseAnnotation{Paths: []string{"**"}, License: "MIT"})
+	checkError(UnsupportedRepositoryError.Error(), err, t)
+}
+
+func TestServiceApplyHeaders(t *testing.T) {
+	// GIVEN
+	repo := NewFakeRepo()
+	svc := NewServiceWithPolicy(&repo, testPolicy)
+	root := t.TempDir()
+
+	if err := svc.CreateFromExpression("Ligen", "Peanut Butter", 2023, 0, Expression{
+		Operator: OR_EXPRESSION,
+		Operands: []Expression{
+			{Operator: SIMPLE_EXPRESSION, LicenseType: MIT},
+			{Operator: SIMPLE_EXPRESSION, LicenseType: APACHE_2_0},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(root, "main.go")
+	if err := os.WriteFile(path, []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// WHEN
+	changes, err := svc.ApplyHeaders("LICENSE", root)
+
+	// THEN
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedChanges := []HeaderChange{{Path: path, Action: HEADER_ADDED}}
+	if !reflect.DeepEqual(expectedChanges, changes) {
+		t.Errorf("Expected %+v, got %+v", expectedChanges, changes)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "// Copyright 2023 Peanut Butter\n// SPDX-License-Identifier: MIT OR Apache-2.0\n\npackage main\n"
+	if string(content) != expected {
+		t.Errorf("Expected %q, got %q", expected, content)
+	}
+}
+
+func TestServiceCheckHeaders(t *testing.T) {
+	// GIVEN
+	repo := NewFakeRepo()
+	svc := NewServiceWithPolicy(&repo, testPolicy)
+	root := t.TempDir()
+
+	if err := svc.Create("Ligen", "Peanut Butter", 2023, 0, MIT); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(root, "main.go")
+	if err := os.WriteFile(path, []byte("// Copyright 2023 Peanut Butter\n// SPDX-License-Identifier: ISC\n\npackage main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// WHEN
+	findings, err := svc.CheckHeaders("LICENSE", root)
+
+	// THEN
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []HeaderFinding{{Path: path, Line: 2, Problem: HEADER_WRONG_LICENSE, SuggestedFix: "// SPDX-License-Identifier: MIT"}}
+	if !reflect.DeepEqual(expected, findings) {
+		t.Errorf("Expected %+v, got %+v", expected, findings)
+	}
+}
+
+func TestServiceBumpYear(t *testing.T) {
+	year := testPolicy.Clock().Year()
+
+	// GIVEN
+	repo := NewFakeRepo()
+	svc := NewServiceWithPolicy(&repo, testPolicy)
+	root := t.TempDir()
+
+	if err := svc.Create("Ligen", "Peanut Butter", year-2, 0, APACHE_2_0); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(root, "main.go")
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("// Copyright %d Peanut Butter\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n", year-2)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	options := BumpYearOptions{
+		HeadersRoot: root,
+		ModifiedYear: func(path string) (int, error) {
+			return year, nil
+		},
+	}
+
+	// WHEN
+	summary, err := svc.BumpYear("LICENSE", options)
+
+	// THEN
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := YearBumpSummary{
+		Previous:       CopyrightYears{Start: year - 2},
+		Current:        CopyrightYears{Start: year - 2, End: year},
+		LicenseUpdated: true,
+		Headers:        []HeaderChange{{Path: path, Action: HEADER_UPDATED}},
+	}
+
+	if !reflect.DeepEqual(expected, summary) {
+		t.Errorf("Expected %+v, got %+v", expected, summary)
+	}
+
+	years, err := svc.GetYears("LICENSE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if years != expected.Current {
+		t.Errorf("Expected %+v, got %+v", expected.Current, years)
+	}
+
+	if !strings.Contains(repo.files["NOTICE"], fmt.Sprintf("%d-%d", year-2, year)) {
+		t.Errorf("Expected the NOTICE to be bumped, got %s", repo.files["NOTICE"])
+	}
+
+	// Running it again has nothing left to do
+	summary, err = svc.BumpYear("LICENSE", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary.LicenseUpdated || len(summary.Headers) != 0 {
+		t.Errorf("Expected no changes, got %+v", summary)
+	}
+}
+
+// FakeHistory hands out a copyright per path, the one for "" being the project's
+type FakeHistory map[string]Copyright
+
+func (f FakeHistory) Copyright(path string) (Copyright, error) {
+	copyright, ok := f[path]
+	if !ok {
+		return f[""], nil
+	}
+
+	return copyright, nil
+}
+
+func TestServiceApplyHeadersFromHistory(t *testing.T) {
+	// GIVEN
+	repo := NewFakeRepo()
+	svc := NewServiceWithPolicy(&repo, testPolicy)
+	root := t.TempDir()
+
+	if err := svc.Create("Ligen", "Peanut Butter", 2023, 0, MIT); err != nil {
+		t.Fatal(err)
+	}
+
+	older := filepath.Join(root, "older.go")
+	newer := filepath.Join(root, "newer.go")
+	for _, path := range []string{older, newer} {
+		if err := os.WriteFile(path, []byte("package main\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history := FakeHistory{
+		"":    {Holder: "Peanut Butter", StartYear: 2023},
+		older: {Holder: "Peanut Butter", StartYear: 2019, EndYear: 2021},
+	}
+
+	// WHEN
+	if _, err := svc.ApplyHeadersFromHistory("LICENSE", root, history); err != nil {
+		t.Fatal(err)
+	}
+
+	// THEN
+	expected := map[string]string{
+		older: "// Copyright 2019-2021 Peanut Butter\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+		newer: "// Copyright 2023 Peanut Butter\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+	}
+
+	for path, content := range expected {
+		current, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(current) != content {
+			t.Errorf("Expected %q, got %q", content, current)
+		}
+	}
+}
+
+func TestServiceUpdateYearsFromGitHistoryUsesPolicy(t *testing.T) {
+	// GIVEN
+	root := gitRepo(t)
+	gitCommit(t, root, "Peanut Butter", "pb@example.com", 2019, "main.go", "package main\n")
+
+	policy := testPolicy
+	policy.MaxYearsPast = 2
+
+	repo := NewFakeRepo()
+	svc := NewServiceWithPolicy(&repo, policy)
+
+	if err := svc.Create("Ligen", "Peanut Butter", 2024, 0, MIT); err != nil {
+		t.Fatal(err)
+	}
+
+	// WHEN
+	err := svc.UpdateYearsFromHistory("LICENSE", NewGitHistory(root, GitHistoryOptions{}))
+
+	// THEN
+	checkError(StartYearTooOldError.Error(), err, t)
+}
+
+func TestServiceUpdateYearsFromHistory(t *testing.T) {
+	// GIVEN
+	repo := NewFakeRepo()
+	svc := NewServiceWithPolicy(&repo, testPolicy)
+
+	if err := svc.Create("Ligen", "Peanut Butter", 2023, 0, MIT); err != nil {
+		t.Fatal(err)
+	}
+
+	history := FakeHistory{"": {Holder: "Jelly", StartYear: 2019, EndYear: 2021}}
+
+	// WHEN
+	if err := svc.UpdateYearsFromHistory("LICENSE", history); err != nil {
+		t.Fatal(err)
+	}
+
+	// THEN
+	years, err := svc.GetYears("LICENSE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := (CopyrightYears{Start: 2019, End: 2021}); years != expected {
+		t.Errorf("Expected %+v, got %+v", expected, years)
+	}
+
+	// The holder stays the project's
+	if !strings.Contains(repo.files["LICENSE"], "Peanut Butter") {
+		t.Errorf("Expected the holder to be kept, got %s", repo.files["LICENSE"])
+	}
+}
@@ -0,0 +1,139 @@
+package ligen
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	InvalidYearsError     = errors.New("invalid copyright years")
+	YearsOutOfOrderError  = errors.New("copyright years must be in ascending order")
+	OverlappingYearsError = errors.New("copyright years must not overlap")
+	OpenRangeNotLastError = errors.New("only the last copyright years can run to the present")
+	EmptyYearsError       = errors.New("copyright must cover at least one year")
+)
+
+// PRESENT is how a range of years that runs to the present is written, e.g. "2019-present"
+const PRESENT = "present"
+
+// YearRange is a single year, or a span of years when End is set.
+type YearRange struct {
+	Start int
+	End   int
+	// Present marks a range that runs to the present, e.g. "2019-present"
+	Present bool
+}
+
+// last returns the last year the range covers, the start year for ranges that run to the present
+func (r YearRange) last() int {
+	return max(r.Start, r.End)
+}
+
+// equal compares ranges, treating a range that starts and ends on the same year as that year
+func (r YearRange) equal(other YearRange) bool {
+	if r.End == r.Start {
+		r.End = 0
+	}
+	if other.End == other.Start {
+		other.End = 0
+	}
+
+	return r == other
+}
+
+// String returns the range as written in a copyright line, e.g. "2020-2022".
+func (r YearRange) String() string {
+	switch {
+	case r.Present:
+		return strconv.Itoa(r.Start) + "-" + PRESENT
+	case r.End != 0:
+		return strconv.Itoa(r.Start) + "-" + strconv.Itoa(r.End)
+	default:
+		return strconv.Itoa(r.Start)
+	}
+}
+
+// YearSet lists the years a copyright covers, e.g. "2018, 2020-2022, 2024".
+type YearSet []YearRange
+
+// String returns the years as written in a copyright line, e.g. "2018, 2020-2022, 2024".
+func (s YearSet) String() string {
+	ranges := make([]string, 0, len(s))
+	for _, r := range s {
+		ranges = append(ranges, r.String())
+	}
+
+	return strings.Join(ranges, ", ")
+}
+
+// Validate checks that the ranges are in ascending order and don't overlap.
+// Only the last range can run to the present.
+func (s YearSet) Validate() error {
+	if len(s) == 0 {
+		return EmptyYearsError
+	}
+
+	for idx, r := range s {
+		if r.End != 0 && r.End < r.Start {
+			return EndYearBeforeStartError
+		}
+
+		if r.Present && idx != len(s)-1 {
+			return OpenRangeNotLastError
+		}
+
+		if idx == 0 {
+			continue
+		}
+
+		previous := s[idx-1]
+		if r.Start < previous.Start {
+			return YearsOutOfOrderError
+		}
+
+		if r.Start <= previous.last() {
+			return OverlappingYearsError
+		}
+	}
+
+	return nil
+}
+
+// yearsPattern matches the years of a copyright line, a comma separated list of years and ranges
+const yearsPattern = `\d{4}(?:\s*-\s*(?:\d{4}|(?i:` + PRESENT + `)))?(?:\s*,\s*\d{4}(?:\s*-\s*(?:\d{4}|(?i:` + PRESENT + `)))?)*`
+
+var yearRangePattern = regexp.MustCompile(`^(\d{4})(?:\s*-\s*(\d{4}|(?i:` + PRESENT + `)))?$`)
+
+// ParseYears parses the years of a copyright line, e.g. "2018, 2020-2022, 2024" or "2019-present".
+func ParseYears(text string) (YearSet, error) {
+	var years YearSet
+
+	for _, part := range strings.Split(text, ",") {
+		matches := yearRangePattern.FindStringSubmatch(strings.TrimSpace(part))
+		if matches == nil {
+			return nil, fmt.Errorf("%w: %s", InvalidYearsError, strings.TrimSpace(text))
+		}
+
+		var r YearRange
+		r.Start, _ = strconv.Atoi(matches[1])
+
+		switch {
+		case matches[2] == "":
+		case strings.EqualFold(matches[2], PRESENT):
+			r.Present = true
+		default:
+			r.End, _ = strconv.Atoi(matches[2])
+		}
+
+		years = append(years, r)
+	}
+
+	if err := years.Validate(); err != nil {
+		return nil, err
+	}
+
+	return years, nil
+}
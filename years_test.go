@@ -0,0 +1,136 @@
+package ligen
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseYears(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expected     YearSet
+		rendered     string
+		errorMessage string
+	}{
+		{
+			name:     "Pass-SingleYear",
+			input:    "2024",
+			expected: YearSet{{Start: 2024}},
+			rendered: "2024",
+		},
+		{
+			name:     "Pass-Range",
+			input:    "2020-2024",
+			expected: YearSet{{Start: 2020, End: 2024}},
+			rendered: "2020-2024",
+		},
+		{
+			name:     "Pass-List",
+			input:    "2018, 2020-2022, 2024",
+			expected: YearSet{{Start: 2018}, {Start: 2020, End: 2022}, {Start: 2024}},
+			rendered: "2018, 2020-2022, 2024",
+		},
+		{
+			name:     "Pass-ListWithoutSpaces",
+			input:    "2018,2020 - 2022",
+			expected: YearSet{{Start: 2018}, {Start: 2020, End: 2022}},
+			rendered: "2018, 2020-2022",
+		},
+		{
+			name:     "Pass-Present",
+			input:    "2019-Present",
+			expected: YearSet{{Start: 2019, Present: true}},
+			rendered: "2019-present",
+		},
+		{
+			name:     "Pass-ListEndingInPresent",
+			input:    "2015, 2019-present",
+			expected: YearSet{{Start: 2015}, {Start: 2019, Present: true}},
+			rendered: "2015, 2019-present",
+		},
+		{
+			name:         "Fail-NotAYear",
+			input:        "2018, soon",
+			errorMessage: "invalid copyright years: 2018, soon",
+		},
+		{
+			name:         "Fail-OutOfOrder",
+			input:        "2024, 2018",
+			errorMessage: YearsOutOfOrderError.Error(),
+		},
+		{
+			name:         "Fail-Overlap",
+			input:        "2018-2021, 2020",
+			errorMessage: OverlappingYearsError.Error(),
+		},
+		{
+			name:         "Fail-RangeBackwards",
+			input:        "2022-2020",
+			errorMessage: EndYearBeforeStartError.Error(),
+		},
+		{
+			name:         "Fail-PresentNotLast",
+			input:        "2018-present, 2024",
+			errorMessage: OpenRangeNotLastError.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			years, err := ParseYears(tc.input)
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			if !reflect.DeepEqual(tc.expected, years) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, years)
+			}
+
+			if years.String() != tc.rendered {
+				t.Errorf("Expected %s, got %s", tc.rendered, years.String())
+			}
+		})
+	}
+}
+
+func TestCopyrightSetYears(t *testing.T) {
+	tests := []struct {
+		name         string
+		years        YearSet
+		start        int
+		end          int
+		errorMessage string
+	}{
+		{name: "Pass-Range", years: YearSet{{Start: 2020, End: 2024}}, start: 2020, end: 2024},
+		{name: "Pass-List", years: YearSet{{Start: 2018}, {Start: 2020, End: 2022}, {Start: 2024}}, start: 2018, end: 2024},
+		{name: "Pass-Present", years: YearSet{{Start: 2019, Present: true}}, start: 2019, end: 0},
+		{name: "Fail-Empty", years: YearSet{}, errorMessage: EmptyYearsError.Error()},
+		{name: "Fail-Overlap", years: YearSet{{Start: 2018, End: 2020}, {Start: 2019}}, errorMessage: OverlappingYearsError.Error()},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			copyright := Copyright{Holder: "Acme", StartYear: 2010}
+
+			err := copyright.SetYears(tc.years)
+			checkError(tc.errorMessage, err, t)
+			if tc.errorMessage != "" {
+				return
+			}
+
+			if copyright.StartYear != tc.start || copyright.EndYear != tc.end {
+				t.Errorf("Expected %d-%d, got %d-%d", tc.start, tc.end, copyright.StartYear, copyright.EndYear)
+			}
+
+			if !reflect.DeepEqual(tc.years, copyright.Years()) {
+				t.Errorf("Expected %+v, got %+v", tc.years, copyright.Years())
+			}
+
+			if err := copyright.Validate(); err != nil {
+				t.Errorf("Expected a valid copyright, got %s", err)
+			}
+		})
+	}
+}